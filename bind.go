@@ -0,0 +1,35 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Bind registers impl's concrete type as the implementation of the interface I.
+// Resolving I resolves the concrete type from the container, so the implementation
+// still has to be registered (e.g. with Inject) and keeps its own lifetime.
+// impl is only used for its type, which the compiler checks against I; pass a typed nil.
+// Usage: injector.Bind[Storage](inj, (*PostgresStorage)(nil))
+func Bind[I any](i *Injector, impl I, opts ...Option) {
+	ifaceType := reflect.TypeOf((*I)(nil)).Elem()
+	if ifaceType.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("injector: Bind target %v is not an interface", ifaceType))
+	}
+
+	implType := reflect.TypeOf(impl)
+	if implType == nil {
+		panic(fmt.Sprintf("injector: Bind implementation for %v must be a typed value, e.g. (*Impl)(nil)", ifaceType))
+	}
+
+	// The binding is a factory func(Impl) I, so the implementation is auto-wired like any
+	// other factory parameter. It is transient by default so the implementation's own
+	// lifetime decides whether the same instance is returned.
+	factoryType := reflect.FuncOf([]reflect.Type{implType}, []reflect.Type{ifaceType}, false)
+	factory := reflect.MakeFunc(factoryType, func(args []reflect.Value) []reflect.Value {
+		out := reflect.New(ifaceType).Elem()
+		out.Set(args[0])
+		return []reflect.Value{out}
+	})
+
+	i.Inject(factory.Interface(), append([]Option{Transient()}, opts...)...)
+}
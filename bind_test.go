@@ -0,0 +1,118 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Storage interface {
+	Save(key string) error
+}
+
+type PostgresStorage struct {
+	DB *Database
+}
+
+func (s *PostgresStorage) Save(key string) error { return nil }
+
+func NewPostgresStorage(db *Database) *PostgresStorage {
+	return &PostgresStorage{DB: db}
+}
+
+type MemoryStorage struct {
+	Items map[string]bool
+}
+
+func (s *MemoryStorage) Save(key string) error { return nil }
+
+type FileService struct {
+	Storage Storage
+}
+
+func NewFileService(storage Storage) *FileService {
+	return &FileService{Storage: storage}
+}
+
+func TestBind_ResolveInterface(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.Inject(NewPostgresStorage)
+	Bind[Storage](inj, (*PostgresStorage)(nil))
+
+	storage, err := Get[Storage](inj)
+	assert.NoError(t, err)
+	assert.IsType(t, &PostgresStorage{}, storage)
+
+	// The binding shares the implementation's singleton
+	assert.Same(t, Must[*PostgresStorage](inj), storage)
+}
+
+func TestBind_ResolveIntoAndInvoke(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&MemoryStorage{})
+	Bind[Storage](inj, (*MemoryStorage)(nil))
+
+	var storage Storage
+	assert.NoError(t, inj.ResolveInto(&storage))
+	assert.IsType(t, &MemoryStorage{}, storage)
+
+	err := inj.Invoke(func(s Storage) {
+		assert.Same(t, storage, s)
+	})
+	assert.NoError(t, err)
+}
+
+func TestBind_AutoWiresInterfaceParameters(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&MemoryStorage{})
+	inj.Inject(NewFileService)
+	Bind[Storage](inj, (*MemoryStorage)(nil))
+
+	svc := Must[*FileService](inj)
+	assert.IsType(t, &MemoryStorage{}, svc.Storage)
+}
+
+func TestBind_FollowsImplementationLifetime(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(func() *MemoryStorage { return &MemoryStorage{Items: map[string]bool{}} }, Transient())
+	Bind[Storage](inj, (*MemoryStorage)(nil))
+
+	s1 := Must[Storage](inj)
+	s2 := Must[Storage](inj)
+	assert.NotSame(t, s1, s2)
+}
+
+func TestBind_MissingImplementation(t *testing.T) {
+	inj := NewInjector()
+	Bind[Storage](inj, (*PostgresStorage)(nil))
+
+	_, err := Get[Storage](inj)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "*injector.PostgresStorage")
+}
+
+func TestBind_RebindReplacesImplementation(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.Inject(NewPostgresStorage)
+	inj.Inject(&MemoryStorage{})
+	Bind[Storage](inj, (*PostgresStorage)(nil))
+	Bind[Storage](inj, (*MemoryStorage)(nil))
+
+	assert.IsType(t, &MemoryStorage{}, Must[Storage](inj))
+}
+
+func TestBind_PanicsOnNonInterface(t *testing.T) {
+	inj := NewInjector()
+	assert.Panics(t, func() {
+		Bind[*PostgresStorage](inj, (*PostgresStorage)(nil))
+	})
+}
+
+func TestBind_PanicsOnUntypedNil(t *testing.T) {
+	inj := NewInjector()
+	assert.Panics(t, func() {
+		Bind[Storage](inj, nil)
+	})
+}
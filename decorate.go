@@ -0,0 +1,134 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Decorate wraps every instance of T registered with Inject or InjectByName in this
+// container or its scopes. decorator is a function whose first parameter is the
+// instance being decorated and whose first result replaces it, optionally followed by
+// an error: func(T, deps...) T or func(T, deps...) (T, error). Other parameters are
+// resolved like factory parameters.
+//
+// Decorators run in registration order, those of a parent container first, whenever an
+// instance is constructed, so singletons are decorated once and cached decorated.
+// Decorators must be registered before T is first resolved; singletons resolved
+// earlier keep their undecorated value. Group members are not decorated.
+// Decorate panics if decorator does not have one of the forms above.
+// Usage: injector.Decorate[Storage](inj, func(s Storage, log *Logger) Storage { return &loggedStorage{s, log} })
+func Decorate[T any](i *Injector, decorator interface{}) {
+	i.decorate(reflect.TypeOf((*T)(nil)).Elem(), decorator)
+}
+
+// decorate registers decorator for the type t.
+func (i *Injector) decorate(t reflect.Type, decorator interface{}) {
+	fn, err := checkDecorator(t, decorator)
+	if err != nil {
+		panic("injector: " + err.Error())
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.decorators[t] = append(i.decorators[t], fn)
+}
+
+// checkDecorator returns decorator as a function value if it is a valid decorator for t.
+func checkDecorator(t reflect.Type, decorator interface{}) (reflect.Value, error) {
+	fn := reflect.ValueOf(decorator)
+	if fn.Kind() != reflect.Func {
+		return reflect.Value{}, fmt.Errorf("decorator for %v must be a function, got %T", t, decorator)
+	}
+
+	ft := fn.Type()
+	returnsError := ft.NumOut() == 2 && ft.Out(1) == errorType
+	if ft.NumIn() == 0 || ft.In(0) != t || (ft.NumOut() != 1 && !returnsError) || !ft.Out(0).AssignableTo(t) {
+		return reflect.Value{}, fmt.Errorf("decorator for %v must be a func(%v, ...) %v, got %v", t, t, t, ft)
+	}
+	return fn, nil
+}
+
+// decoratorsFor returns the decorators that apply to reg, which is registered in this
+// container, root first and in registration order.
+func (i *Injector) decoratorsFor(reg *registration) []reflect.Value {
+	if reg == nil || reg.group != "" || reg.typ == nil {
+		return nil
+	}
+
+	var decorators []reflect.Value
+	for c := i; c != nil; c = c.parent {
+		c.mu.RLock()
+		decorators = append(append([]reflect.Value(nil), c.decorators[reg.typ]...), decorators...)
+		c.mu.RUnlock()
+	}
+	return decorators
+}
+
+// applyDecorators runs the decorators of reg, registered in this container, on instance.
+// Decorator parameters other than the instance are resolved from resolver.
+func (i *Injector) applyDecorators(r *resolution, resolver *Injector, reg *registration, instance interface{}) (interface{}, error) {
+	for _, decorator := range i.decoratorsFor(reg) {
+		ft := decorator.Type()
+
+		args := make([]reflect.Value, ft.NumIn())
+		args[0] = reflect.New(reg.typ).Elem()
+		if v := reflect.ValueOf(instance); v.IsValid() {
+			args[0].Set(v)
+		}
+		for idx := 1; idx < ft.NumIn(); idx++ {
+			arg, err := resolver.resolveArg(r, ft.In(idx))
+			if err != nil {
+				return nil, err
+			}
+			args[idx] = arg
+		}
+
+		results := decorator.Call(args)
+		if err := returnedError(ft, results); err != nil {
+			return nil, &ResolveError{Kind: ErrFactoryFailed, Type: reg.typ, Path: r.path(), Err: err, msg: fmt.Sprintf("decorator for %v failed", reg.typ)}
+		}
+		instance = results[0].Interface()
+	}
+	return instance, nil
+}
+
+// decoratedInstance returns the decorated value of an instance registered in this
+// container with reg, decorating it on first use. Cached singletons were decorated
+// when they were constructed and are returned as they are.
+func (i *Injector) decoratedInstance(r *resolution, reg *registration, dependency interface{}) (interface{}, error) {
+	if reg == nil || reg.isFactory() || len(i.decoratorsFor(reg)) == 0 {
+		return dependency, nil
+	}
+
+	if err := r.enter(reg); err != nil {
+		return nil, err
+	}
+	defer r.leave()
+
+	load := func() (interface{}, bool) {
+		i.mu.RLock()
+		defer i.mu.RUnlock()
+		decorated, ok := i.decorated[reg]
+		return decorated, ok
+	}
+	if decorated, ok := load(); ok {
+		return decorated, nil
+	}
+	if err := reg.construction.lock(reg); err != nil {
+		return nil, err
+	}
+	defer reg.construction.unlock()
+
+	if decorated, ok := load(); ok {
+		return decorated, nil
+	}
+
+	decorated, err := i.applyDecorators(r, i, reg, dependency)
+	if err != nil {
+		return nil, err
+	}
+	i.mu.Lock()
+	i.decorated[reg] = decorated
+	i.mu.Unlock()
+	return decorated, nil
+}
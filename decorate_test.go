@@ -0,0 +1,125 @@
+package injector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type loggedStorage struct {
+	Storage
+	Prefix string
+	Log    *[]string
+}
+
+func (s *loggedStorage) Save(key string) error {
+	*s.Log = append(*s.Log, s.Prefix+key)
+	return s.Storage.Save(key)
+}
+
+func TestDecorate_TypedFactory(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "db"})
+	inj.Inject(func(db *Database) Storage { return NewPostgresStorage(db) })
+
+	var log []string
+	Decorate[Storage](inj, func(s Storage, db *Database) Storage {
+		return &loggedStorage{Storage: s, Prefix: db.Name + ":", Log: &log}
+	})
+
+	storage, err := For[Storage](inj).Resolve()
+	assert.NoError(t, err)
+	assert.NoError(t, storage.Save("users"))
+	assert.Equal(t, []string{"db:users"}, log)
+
+	// The decorated singleton is cached
+	assert.Same(t, storage, Must[Storage](inj))
+	assert.NoError(t, inj.Validate())
+}
+
+func TestDecorate_RegistrationOrder(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "db"})
+
+	Decorate[*Database](inj, func(db *Database) *Database { return &Database{Name: db.Name + "+a"} })
+	Decorate[*Database](inj, func(db *Database) *Database { return &Database{Name: db.Name + "+b"} })
+
+	assert.Equal(t, "db+a+b", Must[*Database](inj).Name)
+	assert.Same(t, Must[*Database](inj), Must[*Database](inj))
+}
+
+func TestDecorate_NamedRegistrations(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectByName(&Database{Name: "primary"}, "primary")
+	inj.InjectByName(func() *Database { return &Database{Name: "replica"} }, "replica", Transient())
+
+	Decorate[*Database](inj, func(db *Database) *Database { return &Database{Name: "decorated-" + db.Name} })
+
+	assert.Equal(t, "decorated-primary", inj.MustResolve("primary").(*Database).Name)
+	assert.Same(t, inj.MustResolve("primary"), inj.MustResolve("primary"))
+	assert.Equal(t, "decorated-replica", inj.MustResolve("replica").(*Database).Name)
+}
+
+func TestDecorate_ParentDecoratorsApplyToScopes(t *testing.T) {
+	inj := NewInjector()
+	Decorate[*RequestContext](inj, func(rc *RequestContext) *RequestContext { return &RequestContext{ID: "root:" + rc.ID} })
+
+	scope := inj.NewScope()
+	Decorate[*RequestContext](scope, func(rc *RequestContext) *RequestContext { return &RequestContext{ID: "scope:" + rc.ID} })
+	scope.Inject(&RequestContext{ID: "req"})
+
+	assert.Equal(t, "scope:root:req", Must[*RequestContext](scope).ID)
+}
+
+func TestDecorate_ScopeDecoratorsDoNotAffectParentRegistrations(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "db"})
+
+	scope := inj.NewScope()
+	Decorate[*Database](scope, func(db *Database) *Database { return &Database{Name: "scoped"} })
+
+	assert.Equal(t, "db", Must[*Database](scope).Name)
+}
+
+func TestDecorate_Errors(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "db"})
+	Decorate[*Database](inj, func(db *Database) (*Database, error) { return nil, errors.New("wrap failed") })
+
+	_, err := Get[*Database](inj)
+	assert.ErrorIs(t, err, ErrFactoryFailed)
+	assert.ErrorContains(t, err, "decorator for *injector.Database failed")
+	assert.ErrorContains(t, err, "wrap failed")
+}
+
+func TestDecorate_MissingDecoratorDependency(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "db"})
+	Decorate[*Database](inj, func(db *Database, rc *RequestContext) *Database { return db })
+
+	_, err := Get[*Database](inj)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, inj.Validate(), ErrNotFound)
+}
+
+func TestDecorate_DependencyOnDecoratedTypeIsACycle(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "db"})
+	inj.Inject(NewUserRepository)
+	Decorate[*Database](inj, func(db *Database, repo *UserRepository) *Database { return db })
+
+	_, err := Get[*Database](inj)
+	assert.ErrorIs(t, err, ErrCycle)
+	assert.ErrorIs(t, inj.Validate(), ErrCycle)
+}
+
+func TestDecorate_InvalidDecoratorPanics(t *testing.T) {
+	inj := NewInjector()
+
+	assert.Panics(t, func() { Decorate[*Database](inj, "not a function") })
+	assert.Panics(t, func() { Decorate[*Database](inj, func() *Database { return nil }) })
+	assert.Panics(t, func() { Decorate[*Database](inj, func(db *Database) {}) })
+	assert.Panics(t, func() { Decorate[*Database](inj, func(db *Database) *UserService { return nil }) })
+	assert.Panics(t, func() { Decorate[*Database](inj, func(db *Database) (*Database, string) { return db, "" }) })
+}
@@ -0,0 +1,183 @@
+package injector
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrEnvMissing means a field tagged required:"true" has no environment variable set.
+var ErrEnvMissing = errors.New("required environment variable is not set")
+
+// EnvError describes a config field that could not be bound from the environment.
+// FromEnv factories return every EnvError joined with errors.Join; use errors.As to
+// inspect the first one:
+//
+//	var envErr *injector.EnvError
+//	if errors.As(err, &envErr) { fmt.Println(envErr.Var, envErr.Field) }
+type EnvError struct {
+	// Var is the environment variable.
+	Var string
+	// Field is the struct field, e.g. "Config.DB.URL".
+	Field string
+	// Value is the value that failed to parse, empty when the variable is missing.
+	Value string
+	// Err is ErrEnvMissing or the parse error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *EnvError) Error() string {
+	if errors.Is(e.Err, ErrEnvMissing) {
+		return fmt.Sprintf("%s: environment variable %s is required but not set", e.Field, e.Var)
+	}
+	return fmt.Sprintf("%s: invalid value %q for %s: %v", e.Field, e.Value, e.Var, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *EnvError) Unwrap() error {
+	return e.Err
+}
+
+// FromEnv returns a factory that builds a *T from environment variables, to register
+// with Inject like any other factory. Fields of the struct T are bound with tags:
+//   - `env:"DB_URL"` names the environment variable
+//   - `default:"..."` is used when the variable is not set
+//   - `required:"true"` makes a missing variable (without default) an error
+//
+// Supported field types are strings, booleans, integers, floats, time.Duration,
+// types implementing encoding.TextUnmarshaler, and slices of these written as
+// comma-separated values. Untagged struct fields are bound recursively.
+// Every missing or invalid variable is reported as an *EnvError, joined with errors.Join,
+// so resolution fails with ErrFactoryFailed and nothing is cached.
+// Usage: inj.Inject(injector.FromEnv[Config]()); cfg, err := injector.For[*Config](inj).Resolve()
+func FromEnv[T any]() func() (*T, error) {
+	return func() (*T, error) {
+		cfg := new(T)
+		v := reflect.ValueOf(cfg).Elem()
+		if v.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("FromEnv requires a struct type, got %v", v.Type())
+		}
+
+		path := v.Type().Name()
+		if path == "" {
+			path = v.Type().String()
+		}
+
+		var errs []error
+		bindEnv(v, path, &errs)
+		if len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+		return cfg, nil
+	}
+}
+
+// bindEnv fills the tagged fields of the struct value v, appending problems to errs.
+// path is the field path of v used in errors.
+func bindEnv(v reflect.Value, path string, errs *[]error) {
+	t := v.Type()
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		fieldPath := path + "." + field.Name
+
+		name, tagged := field.Tag.Lookup("env")
+		if !tagged {
+			if field.Type.Kind() == reflect.Struct && field.IsExported() {
+				bindEnv(v.Field(n), fieldPath, errs)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			*errs = append(*errs, fmt.Errorf("%s: cannot bind unexported field", fieldPath))
+			continue
+		}
+
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			value, ok = field.Tag.Lookup("default")
+		}
+		if !ok {
+			if field.Tag.Get("required") == "true" {
+				*errs = append(*errs, &EnvError{Var: name, Field: fieldPath, Err: ErrEnvMissing})
+			}
+			continue
+		}
+
+		if err := setEnvValue(v.Field(n), value); err != nil {
+			*errs = append(*errs, &EnvError{Var: name, Field: fieldPath, Value: value, Err: err})
+		}
+	}
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// setEnvValue parses value into the field f.
+func setEnvValue(f reflect.Value, value string) error {
+	if f.Addr().Type().Implements(textUnmarshalerType) {
+		return f.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	switch {
+	case f.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(d))
+		return nil
+	case f.Kind() == reflect.Slice:
+		parts := strings.Split(value, ",")
+		if value == "" {
+			parts = nil
+		}
+		slice := reflect.MakeSlice(f.Type(), len(parts), len(parts))
+		for idx, part := range parts {
+			if err := setEnvValue(slice.Index(idx), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		f.Set(slice)
+		return nil
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %v", f.Type())
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package injector
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type DBConfig struct {
+	URL      string `env:"TEST_DB_URL" required:"true"`
+	MaxConns int    `env:"TEST_DB_MAX_CONNS" default:"10"`
+}
+
+type Config struct {
+	DB       DBConfig
+	Debug    bool          `env:"TEST_DEBUG"`
+	Timeout  time.Duration `env:"TEST_TIMEOUT" default:"5s"`
+	Ratio    float64       `env:"TEST_RATIO" default:"0.5"`
+	Hosts    []string      `env:"TEST_HOSTS" default:"a, b"`
+	Ports    []uint16      `env:"TEST_PORTS"`
+	Bind     net.IP        `env:"TEST_BIND" default:"127.0.0.1"`
+	Untagged string
+}
+
+func TestFromEnv_BindsFieldsAndDefaults(t *testing.T) {
+	t.Setenv("TEST_DB_URL", "postgres://localhost/app")
+	t.Setenv("TEST_DEBUG", "true")
+	t.Setenv("TEST_PORTS", "80,443")
+
+	inj := NewInjector()
+	inj.Inject(FromEnv[Config]())
+
+	cfg, err := For[*Config](inj).Resolve()
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{
+		DB:      DBConfig{URL: "postgres://localhost/app", MaxConns: 10},
+		Debug:   true,
+		Timeout: 5 * time.Second,
+		Ratio:   0.5,
+		Hosts:   []string{"a", "b"},
+		Ports:   []uint16{80, 443},
+		Bind:    net.ParseIP("127.0.0.1"),
+	}, cfg)
+}
+
+func TestFromEnv_EnvironmentOverridesDefaults(t *testing.T) {
+	t.Setenv("TEST_DB_URL", "postgres://db/app")
+	t.Setenv("TEST_DB_MAX_CONNS", "42")
+	t.Setenv("TEST_HOSTS", "")
+
+	inj := NewInjector()
+	inj.Inject(FromEnv[Config]())
+	inj.Inject(func(cfg *Config) *Database { return &Database{Name: cfg.DB.URL} })
+
+	assert.Equal(t, "postgres://db/app", Must[*Database](inj).Name)
+	assert.Equal(t, 42, Must[*Config](inj).DB.MaxConns)
+	assert.Empty(t, Must[*Config](inj).Hosts)
+}
+
+func TestFromEnv_StructuredErrors(t *testing.T) {
+	t.Setenv("TEST_DB_MAX_CONNS", "many")
+	t.Setenv("TEST_TIMEOUT", "soon")
+
+	inj := NewInjector()
+	inj.Inject(FromEnv[Config]())
+
+	_, err := Get[*Config](inj)
+	assert.ErrorIs(t, err, ErrFactoryFailed)
+	assert.ErrorIs(t, err, ErrEnvMissing)
+
+	var resolveErr *ResolveError
+	assert.True(t, errors.As(err, &resolveErr))
+	assert.Equal(t, "factory for *injector.Config failed", resolveErr.msg)
+
+	var envErrs []*EnvError
+	for _, e := range resolveErr.Err.(interface{ Unwrap() []error }).Unwrap() {
+		var envErr *EnvError
+		assert.True(t, errors.As(e, &envErr))
+		envErrs = append(envErrs, envErr)
+	}
+	assert.Len(t, envErrs, 3)
+	assert.Equal(t, &EnvError{Var: "TEST_DB_URL", Field: "Config.DB.URL", Err: ErrEnvMissing}, envErrs[0])
+	assert.Equal(t, "TEST_DB_MAX_CONNS", envErrs[1].Var)
+	assert.Equal(t, "many", envErrs[1].Value)
+	assert.Equal(t, "Config.Timeout", envErrs[2].Field)
+
+	assert.ErrorContains(t, err, "Config.DB.URL: environment variable TEST_DB_URL is required but not set")
+	assert.ErrorContains(t, err, `Config.DB.MaxConns: invalid value "many" for TEST_DB_MAX_CONNS`)
+
+	// Nothing is cached, so fixing the environment makes the next resolution succeed
+	t.Setenv("TEST_DB_URL", "postgres://db/app")
+	t.Setenv("TEST_DB_MAX_CONNS", "5")
+	t.Setenv("TEST_TIMEOUT", "1m")
+	cfg, err := Get[*Config](inj)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, cfg.Timeout)
+}
+
+func TestFromEnv_InvalidTargets(t *testing.T) {
+	_, err := FromEnv[string]()()
+	assert.ErrorContains(t, err, "requires a struct type")
+
+	_, err = FromEnv[struct {
+		url string `env:"TEST_DB_URL"`
+	}]()()
+	assert.ErrorContains(t, err, "cannot bind unexported field")
+
+	t.Setenv("TEST_CHAN", "x")
+	_, err = FromEnv[struct {
+		C chan int `env:"TEST_CHAN"`
+	}]()()
+	assert.ErrorContains(t, err, "unsupported field type chan int")
+}
@@ -0,0 +1,111 @@
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors describing why resolution failed. Use them with errors.Is:
+//
+//	if errors.Is(err, injector.ErrNotFound) { ... }
+var (
+	// ErrNotFound means no registration matches the requested type or name.
+	ErrNotFound = errors.New("dependency not found")
+	// ErrTypeMismatch means a registration was found but cannot be used as the requested type.
+	ErrTypeMismatch = errors.New("type mismatch")
+	// ErrFactoryFailed means a factory returned an error.
+	ErrFactoryFailed = errors.New("factory failed")
+	// ErrCycle means a dependency requires itself, directly or indirectly.
+	ErrCycle = errors.New("circular dependency")
+	// ErrAmbiguous means the type-name fallback matched several registered types, or a
+	// slice matched members of several groups.
+	ErrAmbiguous = errors.New("ambiguous dependency")
+)
+
+// ResolveError describes a failed resolution. Use errors.As to inspect it:
+//
+//	var resolveErr *injector.ResolveError
+//	if errors.As(err, &resolveErr) { fmt.Println(resolveErr.Type, resolveErr.Path) }
+type ResolveError struct {
+	// Kind is one of the sentinel errors, e.g. ErrNotFound.
+	Kind error
+	// Type is the requested type, or nil when resolving by name.
+	Type reflect.Type
+	// Name is the requested name or type name, or empty when resolving by type.
+	Name string
+	// Path lists the registrations being constructed when resolution failed, outermost first.
+	Path []string
+	// Err is the underlying error, e.g. the error returned by a factory.
+	Err error
+	// Candidates lists the matching registered types, or groups, when Kind is ErrAmbiguous.
+	Candidates []string
+
+	msg string
+}
+
+// Error implements the error interface.
+func (e *ResolveError) Error() string {
+	msg := e.msg
+	if len(e.Path) > 0 {
+		msg += " (resolving " + strings.Join(e.Path, " -> ") + ")"
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Is reports whether target is the Kind of this error.
+func (e *ResolveError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap returns the underlying error.
+func (e *ResolveError) Unwrap() error {
+	return e.Err
+}
+
+// notFoundError reports a missing registration for a type.
+func notFoundError(r *resolution, t reflect.Type, format string, args ...interface{}) *ResolveError {
+	return &ResolveError{Kind: ErrNotFound, Type: t, Path: r.path(), msg: fmt.Sprintf(format, args...)}
+}
+
+// typeMismatchError reports a resolved instance that cannot be used as t.
+func typeMismatchError(r *resolution, t reflect.Type, format string, args ...interface{}) *ResolveError {
+	return &ResolveError{Kind: ErrTypeMismatch, Type: t, Path: r.path(), msg: fmt.Sprintf(format, args...)}
+}
+
+// ambiguousError reports several registered types sharing the type name requested for t.
+func ambiguousError(r *resolution, t reflect.Type, typeName string, matches []reflect.Type) *ResolveError {
+	candidates := make([]string, len(matches))
+	for idx, match := range matches {
+		candidates[idx] = match.String()
+	}
+	sort.Strings(candidates)
+
+	msg := fmt.Sprintf("ambiguous type name %s", typeName)
+	if t != nil {
+		msg += fmt.Sprintf(" for type %v", t)
+	}
+	msg += ", candidates: " + strings.Join(candidates, ", ")
+	return &ResolveError{Kind: ErrAmbiguous, Type: t, Name: typeName, Path: r.path(), Candidates: candidates, msg: msg}
+}
+
+// CycleError is returned when resolving a dependency requires itself.
+// Path lists the full chain, starting and ending with the same registration.
+type CycleError struct {
+	Path []string
+}
+
+// Error implements the error interface.
+func (e *CycleError) Error() string {
+	return "circular dependency detected: " + strings.Join(e.Path, " -> ")
+}
+
+// Is reports whether target is ErrCycle.
+func (e *CycleError) Is(target error) bool {
+	return target == ErrCycle
+}
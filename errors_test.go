@@ -0,0 +1,100 @@
+package injector
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors_NotFoundByType(t *testing.T) {
+	inj := NewInjector()
+
+	_, err := Get[*Database](inj)
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	var resolveErr *ResolveError
+	assert.True(t, errors.As(err, &resolveErr))
+	assert.Equal(t, reflect.TypeOf(&Database{}), resolveErr.Type)
+	assert.Empty(t, resolveErr.Path)
+}
+
+func TestErrors_NotFoundByName(t *testing.T) {
+	inj := NewInjector()
+
+	_, err := inj.Resolve("database")
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	var resolveErr *ResolveError
+	assert.True(t, errors.As(err, &resolveErr))
+	assert.Equal(t, "database", resolveErr.Name)
+	assert.Nil(t, resolveErr.Type)
+}
+
+func TestErrors_NotFoundByTypeName(t *testing.T) {
+	inj := NewInjector()
+
+	_, err := inj.ResolveByTypeName("Database")
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, "no dependency found for type name Database")
+}
+
+func TestErrors_NotFoundCarriesPath(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewUserService)
+	inj.Inject(NewUserRepository)
+
+	err := inj.Invoke(func(svc *UserService) {})
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	var resolveErr *ResolveError
+	assert.True(t, errors.As(err, &resolveErr))
+	assert.Equal(t, reflect.TypeOf(&Database{}), resolveErr.Type)
+	assert.Equal(t, []string{"*injector.UserService", "*injector.UserRepository"}, resolveErr.Path)
+	assert.EqualError(t, err, "no dependency found for parameter type *injector.Database (resolving *injector.UserService -> *injector.UserRepository)")
+}
+
+func TestErrors_FactoryFailed(t *testing.T) {
+	errConnect := errors.New("connection refused")
+	inj := NewInjector()
+	inj.Inject(func() (*Database, error) { return nil, errConnect })
+	inj.Inject(NewUserRepository)
+
+	_, err := Get[*UserRepository](inj)
+
+	assert.ErrorIs(t, err, ErrFactoryFailed)
+	assert.ErrorIs(t, err, errConnect)
+	var resolveErr *ResolveError
+	assert.True(t, errors.As(err, &resolveErr))
+	assert.Equal(t, reflect.TypeOf(&Database{}), resolveErr.Type)
+	assert.Equal(t, []string{"*injector.UserRepository", "*injector.Database"}, resolveErr.Path)
+	assert.EqualError(t, err, "factory for *injector.Database failed (resolving *injector.UserRepository -> *injector.Database): connection refused")
+}
+
+func TestErrors_TypeMismatch(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "db"})
+
+	// Database matches *Database only through the type name fallback
+	_, err := Get[Database](inj)
+	assert.ErrorIs(t, err, ErrTypeMismatch)
+
+	var db Database
+	assert.ErrorIs(t, inj.ResolveInto(&db), ErrTypeMismatch)
+
+	err = inj.Invoke(func(db Database) {})
+	assert.ErrorIs(t, err, ErrTypeMismatch)
+}
+
+func TestErrors_Cycle(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewUserService)
+	inj.Inject(newCyclicRepository)
+
+	_, err := Get[*UserService](inj)
+
+	assert.ErrorIs(t, err, ErrCycle)
+	assert.NotErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,131 @@
+package injector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Graph is a snapshot of a container's registrations and the dependencies implied by
+// their factory parameter types. Export it with DOT, Mermaid or JSON.
+// Usage: fmt.Println(inj.Graph().Mermaid())
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a registration in the graph.
+type GraphNode struct {
+	// ID identifies the node within the graph, e.g. "n0".
+	ID string `json:"id"`
+	// Label is the registered type, or the quoted name for name-based registrations,
+	// followed by the module for registrations made by a Module.
+	Label string `json:"label"`
+	// Type is the type the registration provides.
+	Type string `json:"type"`
+	// Name is the registered name for name-based registrations.
+	Name string `json:"name,omitempty"`
+	// Group is the group name for registrations made with InjectGroup.
+	Group string `json:"group,omitempty"`
+	// Module is the name of the module that made the registration.
+	Module string `json:"module,omitempty"`
+	// Private is set for private module providers, which only their module can resolve.
+	Private bool `json:"private,omitempty"`
+	// Lifetime is "singleton", "transient" or "scoped"; empty for registered instances.
+	Lifetime string `json:"lifetime,omitempty"`
+}
+
+// GraphEdge points from a registration to a dependency of its factory.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Graph returns the registrations of this container and its ancestors and the edges
+// implied by their factory parameter types. Parameters that cannot be resolved are left
+// out; use Validate to report them. No factory is called.
+func (i *Injector) Graph() *Graph {
+	nodes, edges, _ := i.dependencyGraph()
+
+	g := &Graph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	ids := make(map[*registration]string, len(nodes))
+	for idx, reg := range nodes {
+		node := GraphNode{ID: fmt.Sprintf("n%d", idx), Label: reg.label}
+		if reg.typ != nil {
+			node.Type = reg.typ.String()
+		}
+		if reg.named {
+			node.Name = reg.name
+		}
+		node.Group = reg.group
+		node.Module = reg.module
+		node.Private = reg.private
+		if reg.isFactory() {
+			node.Lifetime = reg.lifetime.String()
+		}
+		ids[reg] = node.ID
+		g.Nodes = append(g.Nodes, node)
+	}
+
+	for _, reg := range nodes {
+		for _, dep := range edges[reg] {
+			g.Edges = append(g.Edges, GraphEdge{From: ids[reg], To: ids[dep]})
+		}
+	}
+	return g
+}
+
+// DOT renders the graph in Graphviz DOT format.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph injector {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, node := range g.Nodes {
+		shape := "box"
+		if node.Lifetime == "" {
+			shape = "ellipse"
+		}
+		fmt.Fprintf(&b, "  %s [label=%q, shape=%s];\n", node.ID, node.text(), shape)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s;\n", edge.From, edge.To)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for _, node := range g.Nodes {
+		text := strings.ReplaceAll(node.text(), `"`, "#quot;")
+		text = strings.ReplaceAll(text, "\n", "<br/>")
+		if node.Lifetime == "" {
+			fmt.Fprintf(&b, "  %s([\"%s\"])\n", node.ID, text)
+		} else {
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", node.ID, text)
+		}
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %s --> %s\n", edge.From, edge.To)
+	}
+	return b.String()
+}
+
+// JSON renders the graph as an indented JSON document.
+func (g *Graph) JSON() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
+
+// text is the human-readable node description used by DOT and Mermaid.
+func (n GraphNode) text() string {
+	text := n.Label
+	if n.Name != "" {
+		text += "\n" + n.Type
+	}
+	if n.Lifetime != "" {
+		text += "\n" + n.Lifetime
+	}
+	return text
+}
@@ -0,0 +1,87 @@
+package injector
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newGraphInjector() *Injector {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "db"})
+	inj.Inject(NewUserRepository)
+	inj.InjectByName(NewUserService, "users", Transient())
+	return inj
+}
+
+func TestGraph_NodesAndEdges(t *testing.T) {
+	g := newGraphInjector().Graph()
+
+	assert.Equal(t, []GraphNode{
+		{ID: "n0", Label: "*injector.Database", Type: "*injector.Database"},
+		{ID: "n1", Label: "*injector.UserRepository", Type: "*injector.UserRepository", Lifetime: "singleton"},
+		{ID: "n2", Label: `"users"`, Type: "*injector.UserService", Name: "users", Lifetime: "transient"},
+	}, g.Nodes)
+	assert.Equal(t, []GraphEdge{
+		{From: "n1", To: "n0"},
+		{From: "n2", To: "n1"},
+	}, g.Edges)
+}
+
+func TestGraph_DOT(t *testing.T) {
+	expected := `digraph injector {
+  rankdir=LR;
+  n0 [label="*injector.Database", shape=ellipse];
+  n1 [label="*injector.UserRepository\nsingleton", shape=box];
+  n2 [label="\"users\"\n*injector.UserService\ntransient", shape=box];
+  n1 -> n0;
+  n2 -> n1;
+}
+`
+	assert.Equal(t, expected, newGraphInjector().Graph().DOT())
+}
+
+func TestGraph_Mermaid(t *testing.T) {
+	expected := `graph LR
+  n0(["*injector.Database"])
+  n1["*injector.UserRepository<br/>singleton"]
+  n2["#quot;users#quot;<br/>*injector.UserService<br/>transient"]
+  n1 --> n0
+  n2 --> n1
+`
+	assert.Equal(t, expected, newGraphInjector().Graph().Mermaid())
+}
+
+func TestGraph_JSON(t *testing.T) {
+	data, err := newGraphInjector().Graph().JSON()
+	assert.NoError(t, err)
+
+	var decoded Graph
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, *newGraphInjector().Graph(), decoded)
+	assert.Contains(t, string(data), `"lifetime": "transient"`)
+}
+
+func TestGraph_DoesNotCallFactories(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(func() *Database {
+		t.Fatal("factory called")
+		return nil
+	})
+	inj.Inject(NewUserRepository)
+	inj.Inject(NewFileService) // Storage is missing and left out
+
+	g := inj.Graph()
+	assert.Len(t, g.Nodes, 3)
+	assert.Equal(t, []GraphEdge{{From: "n1", To: "n0"}}, g.Edges)
+}
+
+func TestGraph_Empty(t *testing.T) {
+	g := NewInjector().Graph()
+
+	assert.Equal(t, "graph LR\n", g.Mermaid())
+	data, err := g.JSON()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"nodes": [], "edges": []}`, string(data))
+}
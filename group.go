@@ -0,0 +1,163 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// InjectGroup adds a dependency to a named group. Unlike Inject, registering several
+// dependencies of the same type keeps all of them. The dependency can be an instance or
+// a factory function whose parameters are resolved by type.
+//
+// Members are resolved together, in registration order, by asking for a slice: Get[[]T],
+// an Invoke or factory parameter of type []T, or ResolveInto(&[]T{}) collects the group
+// members assignable to T, as long as they all belong to one group. Use Group[T] or an
+// In field tagged group to resolve a group by name.
+// Usage: inj.InjectGroup("routes", NewUsersHandler)
+func (i *Injector) InjectGroup(group string, dependency interface{}, opts ...Option) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.injectGroup(group, dependency, opts)
+}
+
+// injectGroup adds a dependency to a named group. The caller must hold i.mu.
+func (i *Injector) injectGroup(group string, dependency interface{}, opts []Option) {
+	depType := reflect.TypeOf(dependency)
+	reg := newRegistration(opts)
+	reg.group = group
+	reg.typ = depType
+	if depType.Kind() == reflect.Func {
+		if depType.NumOut() == 0 {
+			return
+		}
+		reg.factory = reflect.ValueOf(dependency)
+		reg.typ = depType.Out(0)
+	} else {
+		i.groupValues[reg] = dependency
+	}
+	reg.setLabel(fmt.Sprintf("%v (group %q)", reg.typ, group))
+	i.registrations = append(i.registrations, reg)
+}
+
+// Group resolves the members of the named group that are assignable to T, in
+// registration order. It returns an ErrNotFound error if there are none.
+// Usage: routes, err := injector.Group[http.Handler](inj, "routes")
+func Group[T any](i *Injector, name string) ([]T, error) {
+	sliceType := reflect.TypeOf((*[]T)(nil)).Elem()
+
+	r := &resolution{}
+	members := i.groupMembers(name, sliceType.Elem())
+	if len(members) == 0 {
+		return nil, &ResolveError{Kind: ErrNotFound, Type: sliceType, Name: name, msg: fmt.Sprintf("no members found in group %q for type %v", name, sliceType.Elem())}
+	}
+
+	resolved, err := i.resolveGroup(r, sliceType, members)
+	if err != nil {
+		return nil, err
+	}
+	return resolved.([]T), nil
+}
+
+// groupMember is a group registration together with the container it was registered in.
+type groupMember struct {
+	owner *Injector
+	reg   *registration
+}
+
+// groupMembers returns the members of this container and its ancestors, root first and in
+// registration order, whose provided type is assignable to elem. An empty group name
+// matches members of every group.
+func (i *Injector) groupMembers(group string, elem reflect.Type) []groupMember {
+	var members []groupMember
+	for _, c := range i.chain() {
+		c.mu.RLock()
+		for _, reg := range c.registrations {
+			if reg.group == "" || group != "" && reg.group != group {
+				continue
+			}
+			if reg.typ.AssignableTo(elem) {
+				members = append(members, groupMember{owner: c, reg: reg})
+			}
+		}
+		c.mu.RUnlock()
+	}
+	return members
+}
+
+// sliceMembers returns the group members a slice of type sliceType resolves to when no
+// group is named: the members assignable to its element type, which must all belong to
+// the same group. Slices of empty interfaces never resolve to group members, since every
+// member would match.
+func (i *Injector) sliceMembers(r *resolution, sliceType reflect.Type) ([]groupMember, error) {
+	elem := sliceType.Elem()
+	if elem.Kind() == reflect.Interface && elem.NumMethod() == 0 {
+		return nil, nil
+	}
+
+	members := i.groupMembers("", elem)
+	var groups []string
+	for _, m := range members {
+		if !slices.Contains(groups, m.reg.group) {
+			groups = append(groups, m.reg.group)
+		}
+	}
+	if len(groups) > 1 {
+		msg := fmt.Sprintf("%v matches members of groups %s; resolve one group with Group or an In field tagged group", sliceType, strings.Join(groups, ", "))
+		return nil, &ResolveError{Kind: ErrAmbiguous, Type: sliceType, Path: r.path(), Candidates: groups, msg: msg}
+	}
+	return members, nil
+}
+
+// resolveGroup resolves every member into a new slice of type sliceType.
+func (i *Injector) resolveGroup(r *resolution, sliceType reflect.Type, members []groupMember) (interface{}, error) {
+	slice := reflect.MakeSlice(sliceType, 0, len(members))
+	for _, m := range members {
+		value, err := i.resolveGroupMember(r, m.owner, m.reg)
+		if err != nil {
+			return nil, err
+		}
+
+		v := reflect.ValueOf(value)
+		if !v.IsValid() {
+			v = reflect.Zero(sliceType.Elem())
+		}
+		slice = reflect.Append(slice, v)
+	}
+	return slice.Interface(), nil
+}
+
+// resolveGroupMember resolves a single group member registered in owner.
+func (i *Injector) resolveGroupMember(r *resolution, owner *Injector, reg *registration) (interface{}, error) {
+	i.notify(reg)
+	if !reg.isFactory() {
+		owner.mu.RLock()
+		defer owner.mu.RUnlock()
+		return owner.groupValues[reg], nil
+	}
+
+	return i.callFactory(r, owner, reg, reg.factory, cache{
+		load: func() (interface{}, bool) {
+			owner.mu.RLock()
+			defer owner.mu.RUnlock()
+			value, ok := owner.groupValues[reg]
+			return value, ok
+		},
+		store: func(value interface{}) {
+			owner.mu.Lock()
+			defer owner.mu.Unlock()
+			owner.groupValues[reg] = value
+		},
+	})
+}
+
+// memberRegistrations returns the registrations of the given group members.
+func memberRegistrations(members []groupMember) []*registration {
+	regs := make([]*registration, len(members))
+	for idx, m := range members {
+		regs[idx] = m.reg
+	}
+	return regs
+}
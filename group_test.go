@@ -0,0 +1,162 @@
+package injector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type HealthChecker interface {
+	Check() string
+}
+
+type dbChecker struct{ DB *Database }
+
+func (c *dbChecker) Check() string { return "db:" + c.DB.Name }
+
+type staticChecker struct{ Name string }
+
+func (c *staticChecker) Check() string { return c.Name }
+
+func checks(checkers []HealthChecker) []string {
+	var names []string
+	for _, c := range checkers {
+		names = append(names, c.Check())
+	}
+	return names
+}
+
+func newCheckerInjector() *Injector {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.InjectGroup("health", &staticChecker{Name: "static"})
+	inj.InjectGroup("health", func(db *Database) *dbChecker { return &dbChecker{DB: db} })
+	inj.InjectGroup("health", &staticChecker{Name: "last"})
+	return inj
+}
+
+func TestGroup_GetSlice(t *testing.T) {
+	inj := newCheckerInjector()
+
+	checkers, err := Get[[]HealthChecker](inj)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"static", "db:db", "last"}, checks(checkers))
+}
+
+func TestGroup_InvokeAndResolveInto(t *testing.T) {
+	inj := newCheckerInjector()
+
+	err := inj.Invoke(func(checkers []HealthChecker) {
+		assert.Len(t, checkers, 3)
+	})
+	assert.NoError(t, err)
+
+	var checkers []HealthChecker
+	assert.NoError(t, inj.ResolveInto(&checkers))
+	assert.Equal(t, []string{"static", "db:db", "last"}, checks(checkers))
+}
+
+func TestGroup_FactoryParameter(t *testing.T) {
+	inj := newCheckerInjector()
+	inj.Inject(func(checkers []HealthChecker) *Server { return &Server{DB: &Database{Name: checks(checkers)[1]}} })
+
+	assert.Equal(t, "db:db", Must[*Server](inj).DB.Name)
+}
+
+func TestGroup_ConcreteElementType(t *testing.T) {
+	inj := newCheckerInjector()
+
+	// Only members assignable to the element type are collected
+	statics := Must[[]*staticChecker](inj)
+	assert.Len(t, statics, 2)
+}
+
+func TestGroup_ByName(t *testing.T) {
+	inj := newCheckerInjector()
+	inj.InjectGroup("readiness", &staticChecker{Name: "ready"})
+
+	readiness, err := Group[HealthChecker](inj, "readiness")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ready"}, checks(readiness))
+
+	// A slice matching members of several groups has to name one
+	_, err = Get[[]HealthChecker](inj)
+	assert.ErrorIs(t, err, ErrAmbiguous)
+	assert.EqualError(t, err, "[]injector.HealthChecker matches members of groups health, readiness; resolve one group with Group or an In field tagged group")
+	assert.ErrorIs(t, inj.Invoke(func(checkers []HealthChecker) {}), ErrAmbiguous)
+	inj.Inject(func(checkers []HealthChecker) *Server { return &Server{} })
+	assert.ErrorIs(t, inj.Validate(), ErrAmbiguous)
+
+	_, err = Group[HealthChecker](inj, "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGroup_EmptyInterfaceSliceIsNotAGroup(t *testing.T) {
+	inj := newCheckerInjector()
+
+	err := inj.Invoke(func(values []interface{}) {})
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = Get[[]any](inj)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGroup_SingletonMembersAreCached(t *testing.T) {
+	inj := newCheckerInjector()
+
+	first := Must[[]HealthChecker](inj)
+	second := Must[[]HealthChecker](inj)
+	assert.Same(t, first[1], second[1])
+}
+
+func TestGroup_ExplicitSliceRegistrationWins(t *testing.T) {
+	inj := newCheckerInjector()
+	inj.Inject([]HealthChecker{&staticChecker{Name: "explicit"}})
+
+	assert.Equal(t, []string{"explicit"}, checks(Must[[]HealthChecker](inj)))
+}
+
+func TestGroup_ScopeAddsMembers(t *testing.T) {
+	inj := newCheckerInjector()
+	scope := inj.NewScope()
+	scope.InjectGroup("health", &staticChecker{Name: "scope"})
+
+	assert.Equal(t, []string{"static", "db:db", "last", "scope"}, checks(Must[[]HealthChecker](scope)))
+	assert.Len(t, Must[[]HealthChecker](inj), 3)
+}
+
+func TestGroup_MemberErrors(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectGroup("health", func(db *Database) *dbChecker { return &dbChecker{DB: db} })
+
+	_, err := Get[[]HealthChecker](inj)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), `*injector.dbChecker (group "health")`)
+	assert.ErrorIs(t, inj.Validate(), ErrNotFound)
+}
+
+func TestGroup_GraphAndLifecycle(t *testing.T) {
+	started := 0
+	inj := newCheckerInjector()
+	inj.InjectGroup("health", func(db *Database) *dbChecker { return &dbChecker{DB: db} },
+		OnStart(func(ctx context.Context, c *dbChecker) error {
+			started++
+			return nil
+		}))
+	inj.Inject(func(checkers []HealthChecker) *Server { return &Server{} })
+
+	assert.NoError(t, inj.Validate())
+
+	g := inj.Graph()
+	assert.Equal(t, "health", g.Nodes[2].Group)
+	var serverEdges int
+	for _, e := range g.Edges {
+		if e.From == "n5" {
+			serverEdges++
+		}
+	}
+	assert.Equal(t, 4, serverEdges)
+
+	assert.NoError(t, inj.Start(context.Background()))
+	assert.Equal(t, 1, started)
+}
@@ -4,80 +4,438 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 )
 
-// Injector handles dependency registration and resolution
+// Injector handles dependency registration and resolution.
+// It is safe for concurrent registration and resolution.
 type Injector struct {
+	mu sync.RWMutex
+
 	dependencies map[string]interface{}
 	factories    map[string]reflect.Value
 	typeRegistry map[reflect.Type]interface{}
+
+	nameRegistrations map[string]*registration
+	typeRegistrations map[reflect.Type]*registration
+	registrations     []*registration
+
+	// groupValues holds group member instances and cached singletons
+	groupValues map[*registration]interface{}
+
+	// decorators holds the functions registered with Decorate, by decorated type
+	decorators map[reflect.Type][]reflect.Value
+	// decorated caches the decorated value of registered instances
+	decorated map[*registration]interface{}
+
+	// modules holds the modules installed in this container by name
+	modules map[string]*Module
+	// observers are the functions added with Observe
+	observers []*observer
+
+	// privates are the containers holding the private providers of installed modules
+	privates []*Injector
+	// private marks a module's container, whose instances are owned by its parent
+	private bool
+
+	// built records cached instances in the order their construction completed
+	built     []instance
+	lifecycle sync.Mutex
+	started   []instance
+
+	strict     bool
+	parent     *Injector
+	scoped     map[*registration]interface{}
+	scopeLocks map[*registration]*constructionLock
 }
 
 // NewInjector creates a new injector instance
-func NewInjector() *Injector {
-	return &Injector{
-		dependencies: make(map[string]interface{}),
-		factories:    make(map[string]reflect.Value),
-		typeRegistry: make(map[reflect.Type]interface{}),
+func NewInjector(opts ...InjectorOption) *Injector {
+	i := newContainer(nil)
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// newContainer creates an empty container with the given parent (nil for a root container).
+// Scopes inherit the parent's settings.
+func newContainer(parent *Injector) *Injector {
+	i := &Injector{
+		dependencies:      make(map[string]interface{}),
+		factories:         make(map[string]reflect.Value),
+		typeRegistry:      make(map[reflect.Type]interface{}),
+		nameRegistrations: make(map[string]*registration),
+		typeRegistrations: make(map[reflect.Type]*registration),
+		groupValues:       make(map[*registration]interface{}),
+		decorators:        make(map[reflect.Type][]reflect.Value),
+		decorated:         make(map[*registration]interface{}),
+		modules:           make(map[string]*Module),
+		parent:            parent,
+		scoped:            make(map[*registration]interface{}),
+		scopeLocks:        make(map[*registration]*constructionLock),
+	}
+	if parent != nil {
+		i.strict = parent.strict
 	}
+	return i
 }
 
 // InjectByName registers a dependency with a given name.
-// The dependency can be either an instance or a factory function.
-func (i *Injector) InjectByName(dependency interface{}, name string) {
-	depType := reflect.TypeOf(dependency)
+// The dependency can be either an instance or a factory function whose parameters
+// are resolved by type from the container.
+func (i *Injector) InjectByName(dependency interface{}, name string, opts ...Option) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.injectName(dependency, name, opts)
+}
 
+// injectName registers a dependency by name. The caller must hold i.mu.
+func (i *Injector) injectName(dependency interface{}, name string, opts []Option) {
+	depType := reflect.TypeOf(dependency)
+	reg := newNameRegistration(name, opts)
+	reg.typ = depType
 	if depType.Kind() == reflect.Func {
-		i.factories[name] = reflect.ValueOf(dependency)
+		reg.factory = reflect.ValueOf(dependency)
+		if depType.NumOut() > 0 {
+			reg.typ = depType.Out(0)
+		}
+		i.factories[name] = reg.factory
+		delete(i.dependencies, name)
 	} else {
 		i.dependencies[name] = dependency
+		delete(i.factories, name)
 	}
+	i.nameRegistrations[name] = reg
+	i.registrations = append(i.registrations, reg)
 }
 
 // Inject registers a dependency by its type.
 // Factory functions are registered by their return type, instances by their concrete type.
-func (i *Injector) Inject(dependency interface{}) {
+// Factory parameters are resolved by type from the container when the factory is called.
+// Factories may return (T, error); a non-nil error is returned from resolution and nothing is cached.
+// Factories returning a struct that embeds Out also register each of its fields; see Out.
+func (i *Injector) Inject(dependency interface{}, opts ...Option) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.injectType(dependency, opts)
+}
+
+// injectType registers a dependency by its type. The caller must hold i.mu.
+func (i *Injector) injectType(dependency interface{}, opts []Option) {
 	depType := reflect.TypeOf(dependency)
 
+	// Factory functions are registered by their return type
+	var factory reflect.Value
 	if depType.Kind() == reflect.Func {
-		if depType.NumOut() > 0 {
-			returnType := depType.Out(0)
-			fmt.Printf("%+v", returnType)
-			i.typeRegistry[returnType] = dependency
+		factory = reflect.ValueOf(dependency)
+		if depType.NumOut() == 0 {
+			return
 		}
-	} else {
-		i.typeRegistry[depType] = dependency
+		depType = depType.Out(0)
+	}
+
+	reg := newTypeRegistration(depType, opts)
+	reg.factory = factory
+	i.typeRegistry[depType] = dependency
+	i.typeRegistrations[depType] = reg
+	i.registrations = append(i.registrations, reg)
+
+	if factory.IsValid() && embeds(depType, outType) {
+		i.provideOut(reg)
 	}
 }
 
+// currentRegistrations returns this container's registrations in registration order,
+// skipping those replaced by a later registration of the same type or name.
+func (i *Injector) currentRegistrations() []*registration {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var current []*registration
+	for _, reg := range i.registrations {
+		switch {
+		case reg.group != "":
+			// Group members are never replaced
+			current = append(current, reg)
+		case reg.named && i.nameRegistrations[reg.name] == reg, !reg.named && i.typeRegistrations[reg.typ] == reg:
+			current = append(current, reg)
+		}
+	}
+	return current
+}
+
 // ResolveByTypeName resolves a dependency by its type name string (e.g., "Database").
+// It returns an ErrAmbiguous error if several registered types share the name.
 func (i *Injector) ResolveByTypeName(typeName string) (interface{}, error) {
-	for registeredType, dependency := range i.typeRegistry {
-		if i.getTypeName(registeredType) == typeName {
-			return i.resolveRegisteredDependency(dependency, registeredType)
+	r := &resolution{}
+	owner, matches := i.findTypeName(typeName)
+	switch len(matches) {
+	case 0:
+		return nil, &ResolveError{Kind: ErrNotFound, Name: typeName, msg: fmt.Sprintf("no dependency found for type name %s", typeName)}
+	case 1:
+		return i.resolveRegisteredDependency(r, owner, matches[0])
+	default:
+		return nil, ambiguousError(r, nil, typeName, matches)
+	}
+}
+
+// findExactType looks up the container that registered exactly t, nearest container first.
+func (i *Injector) findExactType(t reflect.Type) (*Injector, bool) {
+	for c := i; c != nil; c = c.parent {
+		c.mu.RLock()
+		_, ok := c.typeRegistry[t]
+		c.mu.RUnlock()
+		if ok {
+			return c, true
 		}
 	}
-	return nil, fmt.Errorf("no dependency found for type name %s", typeName)
+	return nil, false
 }
 
-// resolveRegisteredDependency resolves either an instance or calls a factory function.
-// Factory functions are called once and cached (singleton pattern).
-func (i *Injector) resolveRegisteredDependency(dependency interface{}, depType reflect.Type) (interface{}, error) {
-	if reflect.TypeOf(dependency).Kind() != reflect.Func {
-		return dependency, nil
+// findFallbackType looks up a registered type with the same type name as t, unless the
+// container is strict. It fails with ErrAmbiguous if the name is not unique.
+func (i *Injector) findFallbackType(r *resolution, t reflect.Type) (*Injector, reflect.Type, bool, error) {
+	if i.strict {
+		return nil, nil, false, nil
 	}
 
-	factoryValue := reflect.ValueOf(dependency)
-	results := factoryValue.Call([]reflect.Value{})
+	typeName := i.getTypeName(t)
+	owner, matches := i.findTypeName(typeName)
+	switch len(matches) {
+	case 0:
+		return nil, nil, false, nil
+	case 1:
+		return owner, matches[0], true, nil
+	default:
+		return nil, nil, false, ambiguousError(r, t, typeName, matches)
+	}
+}
 
-	if len(results) == 0 {
-		return nil, fmt.Errorf("factory function returned no values")
+// findTypeName returns every registered type with the given type name in the nearest
+// container that has at least one.
+func (i *Injector) findTypeName(typeName string) (*Injector, []reflect.Type) {
+	for c := i; c != nil; c = c.parent {
+		var matches []reflect.Type
+		c.mu.RLock()
+		for registeredType := range c.typeRegistry {
+			if c.getTypeName(registeredType) == typeName {
+				matches = append(matches, registeredType)
+			}
+		}
+		c.mu.RUnlock()
+		if len(matches) > 0 {
+			return c, matches
+		}
+	}
+	return nil, nil
+}
+
+// resolveType resolves a dependency by type from this container and its ancestors.
+// An exact type match wins, then Optional wrappers, Lazy handles and providers, slices
+// of group members and maps of named registrations, then the type-name fallback.
+func (i *Injector) resolveType(r *resolution, t reflect.Type) (interface{}, bool, error) {
+	if owner, ok := i.findExactType(t); ok {
+		instance, err := i.resolveRegisteredDependency(r, owner, t)
+		return instance, true, err
+	}
+
+	if elem, ok := optionalElem(t); ok {
+		instance, err := i.resolveOptional(r, t, elem)
+		return instance, true, err
+	}
+
+	if elem, ok := deferredElem(t); ok {
+		return i.resolveDeferred(t, elem), true, nil
+	}
+
+	if t.Kind() == reflect.Slice {
+		members, err := i.sliceMembers(r, t)
+		if err != nil {
+			return nil, true, err
+		}
+		if len(members) > 0 {
+			instance, err := i.resolveGroup(r, t, members)
+			return instance, true, err
+		}
+	}
+
+	if isNamedMap(t) {
+		if regs := i.namedRegistrations(t.Elem()); len(regs) > 0 {
+			instance, err := i.resolveNamedMap(r, t, regs)
+			return instance, true, err
+		}
+	}
+
+	owner, registeredType, ok, err := i.findFallbackType(r, t)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	instance, err := i.resolveRegisteredDependency(r, owner, registeredType)
+	return instance, true, err
+}
+
+// resolveRegisteredDependency resolves either an instance or calls a factory function
+// registered by type in owner, which is either this container or one of its ancestors.
+func (i *Injector) resolveRegisteredDependency(r *resolution, owner *Injector, depType reflect.Type) (interface{}, error) {
+	owner.mu.RLock()
+	dependency, ok := owner.typeRegistry[depType]
+	reg := owner.typeRegistrations[depType]
+	owner.mu.RUnlock()
+
+	if !ok {
+		return nil, notFoundError(r, depType, "no dependency found for type %v", depType)
+	}
+	i.notify(reg)
+	if !isFactory(dependency) {
+		return owner.decoratedInstance(r, reg, dependency)
+	}
+
+	return i.callFactory(r, owner, reg, reflect.ValueOf(dependency), cache{
+		load: func() (interface{}, bool) {
+			owner.mu.RLock()
+			defer owner.mu.RUnlock()
+			instance, ok := owner.typeRegistry[depType]
+			return instance, ok && !isFactory(instance)
+		},
+		store: func(instance interface{}) {
+			owner.mu.Lock()
+			defer owner.mu.Unlock()
+			// Skip the store if the type was re-registered while the factory was running
+			if owner.typeRegistrations[depType] == reg {
+				owner.typeRegistry[depType] = instance
+			}
+		},
+	})
+}
+
+// isFactory reports whether a registered dependency is a factory function rather than an instance.
+func isFactory(dependency interface{}) bool {
+	return dependency != nil && reflect.TypeOf(dependency).Kind() == reflect.Func
+}
+
+// cache loads and stores the singleton instance of a registration in its owning container.
+type cache struct {
+	load  func() (interface{}, bool)
+	store func(interface{})
+}
+
+// callFactory calls a factory and stores the result according to its lifetime:
+// singletons are cached in the owning container, scoped instances in this container,
+// and transient instances are never cached.
+// Singleton and scoped factories run at most once per cache, even under concurrent
+// first resolution.
+func (i *Injector) callFactory(r *resolution, owner *Injector, reg *registration, factory reflect.Value, singleton cache) (interface{}, error) {
+	// Check for cycles before taking any construction lock, which would otherwise deadlock
+	if err := r.enter(reg); err != nil {
+		return nil, err
+	}
+	defer r.leave()
+
+	switch reg.lifetime {
+	case LifetimeSingleton:
+		// Construction locks are only taken to build, not to read the cache
+		if instance, ok := singleton.load(); ok {
+			return instance, nil
+		}
+		if err := reg.construction.lock(reg); err != nil {
+			return nil, err
+		}
+		defer reg.construction.unlock()
+
+		if instance, ok := singleton.load(); ok {
+			return instance, nil
+		}
+		// Singletons resolve their parameters from the owning container so they
+		// never capture dependencies of a shorter-lived scope
+		instance, err := owner.build(r, owner, reg, factory)
+		if err != nil {
+			return nil, err
+		}
+		singleton.store(instance)
+		owner.record(reg, instance)
+		return instance, nil
+
+	case LifetimeScoped:
+		load := func() (interface{}, bool) {
+			i.mu.RLock()
+			defer i.mu.RUnlock()
+			instance, ok := i.scoped[reg]
+			return instance, ok
+		}
+		if instance, ok := load(); ok {
+			return instance, nil
+		}
+		lock := i.scopeLock(reg)
+		if err := lock.lock(reg); err != nil {
+			return nil, err
+		}
+		defer lock.unlock()
+
+		if instance, ok := load(); ok {
+			return instance, nil
+		}
+		instance, err := owner.build(r, i, reg, factory)
+		if err != nil {
+			return nil, err
+		}
+		i.mu.Lock()
+		i.scoped[reg] = instance
+		i.mu.Unlock()
+		i.record(reg, instance)
+		return instance, nil
+
+	default:
+		return owner.build(r, i, reg, factory)
 	}
+}
 
-	instance := results[0].Interface()
-	i.typeRegistry[depType] = instance
+// build constructs an instance of reg, registered in this container, and decorates it.
+// Parameters are resolved from resolver, or from the module container of a module provider.
+func (i *Injector) build(r *resolution, resolver *Injector, reg *registration, factory reflect.Value) (interface{}, error) {
+	if reg.resolver != nil {
+		resolver = reg.resolver
+	}
+	instance, err := resolver.construct(r, factory)
+	if err != nil {
+		return nil, err
+	}
+	return i.applyDecorators(r, resolver, reg, instance)
+}
+
+// scopeLock returns the lock guarding construction of a scoped registration in this container.
+func (i *Injector) scopeLock(reg *registration) *constructionLock {
+	i.mu.Lock()
+	defer i.mu.Unlock()
 
-	return instance, nil
+	lock, ok := i.scopeLocks[reg]
+	if !ok {
+		lock = &constructionLock{}
+		i.scopeLocks[reg] = lock
+	}
+	return lock
+}
+
+// construct calls a factory with its parameters resolved by type from this container.
+func (i *Injector) construct(r *resolution, factory reflect.Value) (interface{}, error) {
+	args, err := i.resolveArgs(r, factory.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	results := factory.Call(args)
+	if len(results) == 0 {
+		return nil, &ResolveError{Kind: ErrFactoryFailed, Path: r.path(), msg: "factory function returned no values"}
+	}
+	if err := returnedError(factory.Type(), results); err != nil {
+		// Failed instances are never cached, so the next resolution retries the factory
+		outType := factory.Type().Out(0)
+		return nil, &ResolveError{Kind: ErrFactoryFailed, Type: outType, Path: r.path(), Err: err, msg: fmt.Sprintf("factory for %v failed", outType)}
+	}
+
+	return results[0].Interface(), nil
 }
 
 // getTypeName extracts a clean type name, removing package prefixes and pointer markers.
@@ -93,23 +451,63 @@ func (i *Injector) getTypeName(t reflect.Type) string {
 	return name
 }
 
-// Resolve resolves a dependency by its name.
-// Factory functions are called once and cached (singleton pattern).
+// Resolve resolves a dependency by its name from this container and its ancestors.
+// Singleton factories are called once and cached; transient factories are called every time.
 func (i *Injector) Resolve(name string) (interface{}, error) {
-	if dep, exists := i.dependencies[name]; exists {
-		return dep, nil
-	}
+	return i.resolveName(&resolution{}, name)
+}
 
-	if factory, exists := i.factories[name]; exists {
-		results := factory.Call([]reflect.Value{})
-		if len(results) > 0 {
-			instance := results[0].Interface()
-			i.dependencies[name] = instance
-			return instance, nil
+// resolveName resolves a dependency by its name as part of the resolution r.
+func (i *Injector) resolveName(r *resolution, name string) (interface{}, error) {
+	for c := i; c != nil; c = c.parent {
+		c.mu.RLock()
+		dep, hasDep := c.dependencies[name]
+		factory, hasFactory := c.factories[name]
+		reg := c.nameRegistrations[name]
+		c.mu.RUnlock()
+
+		if hasDep || hasFactory {
+			i.notify(reg)
+		}
+		if hasDep {
+			return c.decoratedInstance(r, reg, dep)
+		}
+
+		if hasFactory {
+			owner := c
+			return i.callFactory(r, owner, reg, factory, cache{
+				load: func() (interface{}, bool) {
+					owner.mu.RLock()
+					defer owner.mu.RUnlock()
+					instance, ok := owner.dependencies[name]
+					return instance, ok
+				},
+				store: func(instance interface{}) {
+					owner.mu.Lock()
+					defer owner.mu.Unlock()
+					// Skip the store if the name was re-registered while the factory was running
+					if owner.nameRegistrations[name] == reg {
+						owner.dependencies[name] = instance
+					}
+				},
+			})
 		}
 	}
 
-	return nil, fmt.Errorf("dependency '%s' not found", name)
+	return nil, &ResolveError{Kind: ErrNotFound, Name: name, Path: r.path(), msg: fmt.Sprintf("dependency '%s' not found", name)}
+}
+
+// findName returns the registration for name in this container or its nearest ancestor.
+func (i *Injector) findName(name string) (*registration, bool) {
+	for c := i; c != nil; c = c.parent {
+		c.mu.RLock()
+		reg, ok := c.nameRegistrations[name]
+		c.mu.RUnlock()
+		if ok {
+			return reg, true
+		}
+	}
+	return nil, false
 }
 
 // MustResolve is like Resolve but panics if the dependency is not found.
@@ -138,46 +536,18 @@ func (tr *TypeResolver[T]) Resolve() (T, error) {
 	var zero T
 	targetType := reflect.TypeOf((*T)(nil)).Elem()
 
-	if dependency, exists := tr.injector.typeRegistry[targetType]; exists {
-		return tr.resolveDependency(dependency, targetType)
-	}
-
-	typeName := tr.injector.getTypeName(targetType)
-	for registeredType, dependency := range tr.injector.typeRegistry {
-		if tr.injector.getTypeName(registeredType) == typeName {
-			return tr.resolveDependency(dependency, registeredType)
-		}
+	r := &resolution{}
+	instance, found, err := tr.injector.resolveType(r, targetType)
+	if err != nil {
+		return zero, err
 	}
-
-	return zero, fmt.Errorf("no dependency found for type %v", targetType)
-}
-
-// resolveDependency resolves and casts a registered dependency to the target type.
-// Factory functions are called once and cached (singleton pattern).
-func (tr *TypeResolver[T]) resolveDependency(dependency interface{}, depType reflect.Type) (T, error) {
-	var zero T
-
-	if reflect.TypeOf(dependency).Kind() != reflect.Func {
-		result, ok := dependency.(T)
-		if !ok {
-			return zero, fmt.Errorf("type mismatch: cannot cast to %T", zero)
-		}
-		return result, nil
+	if !found {
+		return zero, notFoundError(r, targetType, "no dependency found for type %v", targetType)
 	}
 
-	factoryValue := reflect.ValueOf(dependency)
-	results := factoryValue.Call([]reflect.Value{})
-
-	if len(results) == 0 {
-		return zero, fmt.Errorf("factory function returned no values")
-	}
-
-	instance := results[0].Interface()
-	tr.injector.typeRegistry[depType] = instance
-
 	result, ok := instance.(T)
 	if !ok {
-		return zero, fmt.Errorf("type mismatch: cannot cast to %T", zero)
+		return zero, typeMismatchError(r, targetType, "type mismatch: cannot cast %T to %v", instance, targetType)
 	}
 
 	return result, nil
@@ -232,38 +602,25 @@ func (i *Injector) ResolveInto(target interface{}) error {
 	// Desired element type to assign to (e.g., *injector.Database)
 	elemType := v.Elem().Type()
 
-	// Try exact match first
-	if dep, ok := i.typeRegistry[elemType]; ok {
-		inst, err := i.resolveRegisteredDependency(dep, elemType)
-		if err != nil {
-			return err
-		}
-		rv := reflect.ValueOf(inst)
-		if !rv.Type().AssignableTo(elemType) {
-			return fmt.Errorf("resolved type %v is not assignable to %v", rv.Type(), elemType)
-		}
-		v.Elem().Set(rv)
-		return nil
+	// Exact match first, then fallback by type name (e.g., Database vs *pkg.Database)
+	r := &resolution{}
+	inst, found, err := i.resolveType(r, elemType)
+	if err != nil {
+		return err
 	}
-
-	// Fallback: match by type name (e.g., Database vs *pkg.Database)
-	typeName := i.getTypeName(elemType)
-	for registeredType, dep := range i.typeRegistry {
-		if i.getTypeName(registeredType) == typeName {
-			inst, err := i.resolveRegisteredDependency(dep, registeredType)
-			if err != nil {
-				return err
-			}
-			rv := reflect.ValueOf(inst)
-			if !rv.Type().AssignableTo(elemType) {
-				return fmt.Errorf("resolved type %v is not assignable to %v", rv.Type(), elemType)
-			}
-			v.Elem().Set(rv)
-			return nil
-		}
+	if !found {
+		return notFoundError(r, elemType, "no dependency found for type %v", elemType)
 	}
 
-	return fmt.Errorf("no dependency found for type %v", elemType)
+	rv := reflect.ValueOf(inst)
+	if !rv.IsValid() {
+		rv = reflect.Zero(elemType)
+	}
+	if !rv.Type().AssignableTo(elemType) {
+		return typeMismatchError(r, elemType, "resolved type %v is not assignable to %v", rv.Type(), elemType)
+	}
+	v.Elem().Set(rv)
+	return nil
 }
 
 // Invoke calls the provided function, resolving its parameters by type from the injector.
@@ -278,52 +635,50 @@ func (i *Injector) Invoke(fn interface{}) error {
 		return fmt.Errorf("fn must be a function")
 	}
 
-	// Build argument list by resolving each parameter type
+	args, err := i.resolveArgs(&resolution{}, ft)
+	if err != nil {
+		return err
+	}
+
+	results := fv.Call(args)
+	// If last return is error, propagate it
+	return returnedError(ft, results)
+}
+
+// resolveArgs builds the argument list for a function by resolving each parameter type.
+// Parameters that embed In are built field by field.
+func (i *Injector) resolveArgs(r *resolution, ft reflect.Type) ([]reflect.Value, error) {
 	args := make([]reflect.Value, ft.NumIn())
 	for idx := 0; idx < ft.NumIn(); idx++ {
-		pType := ft.In(idx)
-
-		// Try exact type match
-		if dep, ok := i.typeRegistry[pType]; ok {
-			inst, err := i.resolveRegisteredDependency(dep, pType)
-			if err != nil {
-				return err
-			}
-			args[idx] = reflect.ValueOf(inst)
-			continue
+		arg, err := i.resolveArg(r, ft.In(idx))
+		if err != nil {
+			return nil, err
 		}
+		args[idx] = arg
+	}
+	return args, nil
+}
 
-		// Fallback by type name
-		var (
-			found bool
-			val   interface{}
-		)
-		for registeredType, dep := range i.typeRegistry {
-			if i.getTypeName(registeredType) == i.getTypeName(pType) {
-				inst, err := i.resolveRegisteredDependency(dep, registeredType)
-				if err != nil {
-					return err
-				}
-				val = inst
-				found = true
-				break
-			}
-		}
-		if !found {
-			return fmt.Errorf("no dependency found for parameter type %v", pType)
-		}
-		args[idx] = reflect.ValueOf(val)
+// resolveArg resolves a single function parameter of type pType.
+func (i *Injector) resolveArg(r *resolution, pType reflect.Type) (reflect.Value, error) {
+	if embeds(pType, inType) {
+		return i.resolveIn(r, pType)
 	}
+	// Exact type match first, then fallback by type name
+	return i.resolveParam(r, dependency{typ: pType})
+}
 
-	results := fv.Call(args)
-	// If last return is error, propagate it
-	if ft.NumOut() > 0 {
-		lastIdx := ft.NumOut() - 1
-		if ft.Out(lastIdx) == reflect.TypeOf((*error)(nil)).Elem() {
-			if !results[lastIdx].IsNil() {
-				return results[lastIdx].Interface().(error)
-			}
-		}
+// errorType is the reflect.Type of the error interface.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// returnedError returns the error a function returned as its last result, if any.
+func returnedError(ft reflect.Type, results []reflect.Value) error {
+	if ft.NumOut() == 0 {
+		return nil
 	}
-	return nil
+	lastIdx := ft.NumOut() - 1
+	if ft.Out(lastIdx) != errorType || results[lastIdx].IsNil() {
+		return nil
+	}
+	return results[lastIdx].Interface().(error)
 }
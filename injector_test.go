@@ -1,9 +1,13 @@
 package injector
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -373,6 +377,251 @@ func TestInvoke_WithErrorReturn(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestInject_FactoryWithParameters(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.Inject(NewUserRepository)
+	inj.Inject(NewUserService)
+
+	svc, err := Get[*UserService](inj)
+	assert.NoError(t, err)
+	assert.NotNil(t, svc.Repo)
+	assert.Same(t, Must[*Database](inj), svc.Repo.DB)
+	assert.Same(t, Must[*UserRepository](inj), svc.Repo)
+}
+
+func TestInjectByName_FactoryWithParameters(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.InjectByName(NewUserRepository, "userRepository")
+
+	resolved, err := inj.Resolve("userRepository")
+	assert.NoError(t, err)
+	assert.Equal(t, "db", resolved.(*UserRepository).DB.Name)
+}
+
+func TestInject_FactoryWithMissingParameter(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewUserRepository)
+
+	_, err := Get[*UserRepository](inj)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no dependency found for parameter type *injector.Database")
+}
+
+func TestInvoke_WithAutoWiredFactories(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewUserService)
+	inj.Inject(NewUserRepository)
+	inj.Inject(NewDB)
+
+	err := inj.Invoke(func(svc *UserService, db *Database) {
+		assert.Same(t, db, svc.Repo.DB)
+	})
+	assert.NoError(t, err)
+}
+
+func TestInject_FactoryReturningError(t *testing.T) {
+	errConnect := errors.New("connection refused")
+	attempts := 0
+	openDB := func() (*Database, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errConnect
+		}
+		return &Database{Name: "opened"}, nil
+	}
+
+	inj := NewInjector()
+	inj.Inject(openDB)
+
+	_, err := Get[*Database](inj)
+	assert.ErrorIs(t, err, errConnect)
+	assert.Contains(t, err.Error(), "factory for *injector.Database failed")
+
+	// The failed instance is not cached, so the factory runs again
+	db, err := Get[*Database](inj)
+	assert.NoError(t, err)
+	assert.Equal(t, "opened", db.Name)
+	assert.Same(t, db, Must[*Database](inj))
+	assert.Equal(t, 2, attempts)
+}
+
+func TestInject_FactoryErrorSurfacesEverywhere(t *testing.T) {
+	errConnect := errors.New("connection refused")
+	openDB := func() (*Database, error) {
+		return nil, errConnect
+	}
+
+	inj := NewInjector()
+	inj.Inject(openDB)
+	inj.InjectByName(openDB, "database")
+
+	_, err := inj.Resolve("database")
+	assert.ErrorIs(t, err, errConnect)
+	assert.Equal(t, 0, len(inj.dependencies))
+
+	var db *Database
+	assert.ErrorIs(t, inj.ResolveInto(&db), errConnect)
+	assert.Nil(t, db)
+
+	called := false
+	err = inj.Invoke(func(db *Database) { called = true })
+	assert.ErrorIs(t, err, errConnect)
+	assert.False(t, called)
+
+	inj.Inject(NewUserRepository)
+	_, err = Get[*UserRepository](inj)
+	assert.ErrorIs(t, err, errConnect)
+}
+
+func TestConcurrent_SingletonFactoryRunsOnce(t *testing.T) {
+	inj := NewInjector()
+	var calls int32
+	inj.Inject(func() *Database {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return &Database{Name: "db"}
+	})
+
+	const workers = 50
+	results := make([]*Database, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			results[w] = Must[*Database](inj)
+		}(w)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, db := range results {
+		assert.Same(t, results[0], db)
+	}
+}
+
+func TestConcurrent_NamedSingletonFactoryRunsOnce(t *testing.T) {
+	inj := NewInjector()
+	var calls int32
+	inj.InjectByName(func() *Database {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return &Database{Name: "db"}
+	}, "database")
+
+	var wg sync.WaitGroup
+	for w := 0; w < 50; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			inj.MustResolve("database")
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestConcurrent_ScopedFactoryRunsOncePerScope(t *testing.T) {
+	inj := NewInjector()
+	var calls int32
+	inj.Inject(func() *RequestID {
+		return &RequestID{Value: int(atomic.AddInt32(&calls, 1))}
+	}, Scoped())
+
+	scopes := []*Injector{inj.NewScope(), inj.NewScope(), inj.NewScope()}
+	var wg sync.WaitGroup
+	for _, scope := range scopes {
+		for w := 0; w < 20; w++ {
+			wg.Add(1)
+			go func(scope *Injector) {
+				defer wg.Done()
+				Must[*RequestID](scope)
+			}(scope)
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(len(scopes)), atomic.LoadInt32(&calls))
+}
+
+func TestConcurrent_RegisterAndResolve(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.Inject(NewUserRepository)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 20; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			inj.InjectByName(&Database{Name: fmt.Sprintf("db-%d", w)}, fmt.Sprintf("db-%d", w))
+			inj.Inject(newRequestIDFactory(), Transient())
+		}(w)
+		go func() {
+			defer wg.Done()
+			repo := Must[*UserRepository](inj)
+			assert.NotNil(t, repo.DB)
+			_, _ = inj.ResolveByTypeName("RequestID")
+			_ = inj.Invoke(func(db *Database) {})
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 20, len(inj.dependencies))
+}
+
+// newOtherDatabase returns an instance of a second type whose short name is also
+// Database, as if it came from another package.
+func newOtherDatabase(dsn string) interface{} {
+	type Database struct{ DSN string }
+	return &Database{DSN: dsn}
+}
+
+func TestTypeNameFallback_Ambiguous(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.Inject(newOtherDatabase("other"))
+
+	_, err := inj.ResolveByTypeName("Database")
+	assert.ErrorIs(t, err, ErrAmbiguous)
+
+	var resolveErr *ResolveError
+	assert.True(t, errors.As(err, &resolveErr))
+	assert.Equal(t, []string{"*injector.Database", "*injector.Database"}, resolveErr.Candidates)
+	assert.Contains(t, err.Error(), "ambiguous type name Database")
+
+	// Exact matches are never ambiguous
+	assert.Equal(t, "db", Must[*Database](inj).Name)
+
+	// The value type only matches through the fallback, which now has two candidates
+	_, err = Get[Database](inj)
+	assert.ErrorIs(t, err, ErrAmbiguous)
+	assert.True(t, errors.As(err, &resolveErr))
+	assert.Equal(t, reflect.TypeOf(Database{}), resolveErr.Type)
+
+	err = inj.Invoke(func(db Database) {})
+	assert.ErrorIs(t, err, ErrAmbiguous)
+
+	var db Database
+	assert.ErrorIs(t, inj.ResolveInto(&db), ErrAmbiguous)
+}
+
+func TestTypeNameFallback_NearestScopeWins(t *testing.T) {
+	root := NewInjector()
+	root.Inject(NewDB)
+	root.Inject(newOtherDatabase("other"))
+
+	scope := root.NewScope()
+	scope.Inject(newOtherDatabase("scope"))
+
+	resolved, err := scope.ResolveByTypeName("Database")
+	assert.NoError(t, err)
+	assert.Equal(t, "scope", reflect.ValueOf(resolved).Elem().FieldByName("DSN").String())
+}
+
 // Benchmark tests
 func BenchmarkInjectInstance(b *testing.B) {
 	injector := NewInjector()
@@ -450,3 +699,7 @@ func NewUserRepository(db *Database) *UserRepository {
 type UserService struct {
 	Repo *UserRepository
 }
+
+func NewUserService(repo *UserRepository) *UserService {
+	return &UserService{Repo: repo}
+}
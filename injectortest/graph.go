@@ -0,0 +1,40 @@
+package injectortest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Javlopez/injector"
+)
+
+// UpdateEnv is the environment variable that makes AssertGraph write golden files
+// instead of comparing against them, e.g. INJECTORTEST_UPDATE=1 go test ./...
+const UpdateEnv = "INJECTORTEST_UPDATE"
+
+// AssertGraph compares the DOT rendering of inj's dependency graph with the golden file
+// and fails the test if they differ. When UpdateEnv is set, the golden file is written
+// instead, creating its directory if needed.
+// Usage: injectortest.AssertGraph(t, inj, "testdata/wiring.dot")
+func AssertGraph(t testing.TB, inj *injector.Injector, golden string) {
+	t.Helper()
+	got := inj.Graph().DOT()
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+			t.Fatalf("injectortest: update %s: %v", golden, err)
+		}
+		if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+			t.Fatalf("injectortest: update %s: %v", golden, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("injectortest: read %s: %v (run with %s=1 to create it)", golden, err, UpdateEnv)
+	}
+	if string(want) != got {
+		t.Errorf("injectortest: dependency graph does not match %s (run with %s=1 to update it)\nwant:\n%s\ngot:\n%s", golden, UpdateEnv, want, got)
+	}
+}
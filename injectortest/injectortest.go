@@ -0,0 +1,56 @@
+// Package injectortest provides helpers for tests that use an injector.Injector.
+package injectortest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Javlopez/injector"
+)
+
+// NewTestInjector creates an injector that is stopped and closed when the test and its
+// subtests complete. Errors from Stop and Close fail the test.
+// Usage: inj := injectortest.NewTestInjector(t)
+func NewTestInjector(t testing.TB, opts ...injector.InjectorOption) *injector.Injector {
+	t.Helper()
+	inj := injector.NewInjector(opts...)
+	t.Cleanup(func() {
+		if err := inj.Stop(context.Background()); err != nil {
+			t.Errorf("injectortest: stop: %v", err)
+		}
+		if err := inj.Close(); err != nil {
+			t.Errorf("injectortest: close: %v", err)
+		}
+	})
+	return inj
+}
+
+// RequireResolvable resolves T from inj and stops the test if it cannot be resolved.
+// Usage: svc := injectortest.RequireResolvable[*UserService](t, inj)
+func RequireResolvable[T any](t testing.TB, inj *injector.Injector) T {
+	t.Helper()
+	value, err := injector.Get[T](inj)
+	if err != nil {
+		t.Fatalf("injectortest: %v is not resolvable: %v", reflect.TypeOf((*T)(nil)).Elem(), err)
+	}
+	return value
+}
+
+// Replace registers fake as the T of inj for the rest of the test, replacing the existing
+// registration and any singleton it already cached. The original is restored when the
+// test and its subtests complete, and singletons built on fake meanwhile are dropped.
+// fake is returned on every resolution and is never closed by inj.
+// Usage: injectortest.Replace[Storage](t, inj, &FakeStorage{})
+func Replace[T any](t testing.TB, inj *injector.Injector, fake T) {
+	t.Helper()
+	t.Cleanup(inj.Override(func() T { return fake }, injector.Transient()))
+}
+
+// ReplaceNamed registers fake under name for the rest of the test, replacing the existing
+// registration. The original is restored when the test and its subtests complete.
+// Usage: injectortest.ReplaceNamed(t, inj, "primary", fakeDB)
+func ReplaceNamed(t testing.TB, inj *injector.Injector, name string, fake interface{}) {
+	t.Helper()
+	t.Cleanup(inj.OverrideByName(fake, name))
+}
@@ -0,0 +1,187 @@
+package injectortest
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/Javlopez/injector"
+	"github.com/stretchr/testify/assert"
+)
+
+type Database struct {
+	Name string
+}
+
+type Store interface {
+	Get(key string) string
+}
+
+type dbStore struct {
+	DB *Database
+}
+
+func (s *dbStore) Get(key string) string { return s.DB.Name + ":" + key }
+
+type fakeStore struct{}
+
+func (fakeStore) Get(key string) string { return "fake:" + key }
+
+func newInjector() *injector.Injector {
+	inj := injector.NewInjector()
+	inj.Inject(func() *Database { return &Database{Name: "real"} })
+	inj.Inject(func(db *Database) Store { return &dbStore{DB: db} })
+	return inj
+}
+
+func TestReplace(t *testing.T) {
+	inj := newInjector()
+	original := injector.Must[Store](inj)
+
+	t.Run("with fake", func(t *testing.T) {
+		Replace[Store](t, inj, fakeStore{})
+		assert.Equal(t, "fake:k", injector.Must[Store](inj).Get("k"))
+	})
+
+	assert.Same(t, original, injector.Must[Store](inj))
+}
+
+func TestReplace_InfersType(t *testing.T) {
+	inj := newInjector()
+	fake := &Database{Name: "fake"}
+
+	t.Run("with fake", func(t *testing.T) {
+		Replace(t, inj, fake)
+		assert.Same(t, fake, injector.Must[*Database](inj))
+		assert.Equal(t, "fake:k", injector.Must[Store](inj).Get("k"))
+	})
+
+	assert.Equal(t, "real", injector.Must[*Database](inj).Name)
+	// Store was built on the fake, so it is built again after the replacement ends
+	assert.Equal(t, "real:k", injector.Must[Store](inj).Get("k"))
+}
+
+func TestReplaceNamed(t *testing.T) {
+	inj := injector.NewInjector()
+	inj.InjectByName(&Database{Name: "primary"}, "primary")
+
+	t.Run("with fake", func(t *testing.T) {
+		ReplaceNamed(t, inj, "primary", &Database{Name: "fake"})
+		assert.Equal(t, "fake", inj.MustResolve("primary").(*Database).Name)
+	})
+
+	assert.Equal(t, "primary", inj.MustResolve("primary").(*Database).Name)
+}
+
+// fakeT records failures instead of failing the test.
+type fakeT struct {
+	testing.TB
+	errors   []string
+	fatals   []string
+	cleanups []func()
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Fatalf(format string, args ...interface{}) {
+	f.fatals = append(f.fatals, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func (f *fakeT) runCleanups() {
+	for idx := len(f.cleanups) - 1; idx >= 0; idx-- {
+		f.cleanups[idx]()
+	}
+}
+
+type closingDatabase struct {
+	closed *bool
+}
+
+func (d *closingDatabase) Close() error {
+	*d.closed = true
+	return nil
+}
+
+func TestNewTestInjector_ClosesOnCleanup(t *testing.T) {
+	closed := false
+	ft := &fakeT{TB: t}
+
+	inj := NewTestInjector(ft)
+	inj.Inject(func() *closingDatabase { return &closingDatabase{closed: &closed} })
+	injector.Must[*closingDatabase](inj)
+
+	ft.runCleanups()
+	assert.True(t, closed)
+	assert.Empty(t, ft.errors)
+}
+
+func TestNewTestInjector_ReportsCloseErrors(t *testing.T) {
+	ft := &fakeT{TB: t}
+	inj := NewTestInjector(ft, injector.StrictTypes())
+	inj.Inject(func() *Database { return &Database{} }, injector.OnClose(func(*Database) error { return errors.New("still busy") }))
+	injector.Must[*Database](inj)
+
+	ft.runCleanups()
+	assert.Len(t, ft.errors, 1)
+	assert.Contains(t, ft.errors[0], "still busy")
+}
+
+func TestRequireResolvable(t *testing.T) {
+	inj := newInjector()
+	assert.Equal(t, "real:k", RequireResolvable[Store](t, inj).Get("k"))
+
+	ft := &fakeT{TB: t}
+	RequireResolvable[*dbStore](ft, inj)
+	assert.Len(t, ft.fatals, 1)
+	assert.Contains(t, ft.fatals[0], "*injectortest.dbStore is not resolvable")
+}
+
+func TestAssertGraph(t *testing.T) {
+	AssertGraph(t, newInjector(), "testdata/graph.dot")
+
+	golden := filepath.Join(t.TempDir(), "nested", "graph.dot")
+
+	ft := &fakeT{TB: t}
+	AssertGraph(ft, newInjector(), golden)
+	assert.Len(t, ft.fatals, 1)
+	assert.Contains(t, ft.fatals[0], UpdateEnv+"=1")
+
+	t.Setenv(UpdateEnv, "1")
+	AssertGraph(t, newInjector(), golden)
+	t.Setenv(UpdateEnv, "")
+	AssertGraph(t, newInjector(), golden)
+
+	changed := newInjector()
+	changed.InjectByName(&Database{}, "replica")
+	ft = &fakeT{TB: t}
+	AssertGraph(ft, changed, golden)
+	assert.Len(t, ft.errors, 1)
+	assert.Contains(t, ft.errors[0], `replica`)
+}
+
+func TestRecord(t *testing.T) {
+	inj := newInjector()
+	inj.InjectByName(&Database{Name: "unused"}, "replica")
+
+	ft := &fakeT{TB: t}
+	rec := Record(ft, inj)
+	injector.Must[Store](inj)
+	injector.Must[Store](inj)
+
+	assert.Equal(t, []string{"injectortest.Store", "*injectortest.Database"}, rec.Used())
+	assert.Equal(t, []string{`"replica"`}, rec.Unused())
+
+	// Recording stops on cleanup
+	ft.runCleanups()
+	inj.MustResolve("replica")
+	assert.Equal(t, []string{`"replica"`}, rec.Unused())
+}
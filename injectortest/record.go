@@ -0,0 +1,61 @@
+package injectortest
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Javlopez/injector"
+)
+
+// Recorder lists the registrations resolved from an injector while a test runs.
+type Recorder struct {
+	inj *injector.Injector
+
+	mu   sync.Mutex
+	used []string
+	seen map[string]bool
+}
+
+// Record starts recording the registrations resolved from inj and its scopes. Recording
+// stops when the test and its subtests complete.
+// Usage: rec := injectortest.Record(t, inj); ...; t.Log(rec.Unused())
+func Record(t testing.TB, inj *injector.Injector) *Recorder {
+	t.Helper()
+	rec := &Recorder{inj: inj, seen: make(map[string]bool)}
+	t.Cleanup(inj.Observe(rec.observe))
+	return rec
+}
+
+// observe records the first resolution of each registration.
+func (rec *Recorder) observe(e injector.ResolveEvent) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if !rec.seen[e.Label] {
+		rec.seen[e.Label] = true
+		rec.used = append(rec.used, e.Label)
+	}
+}
+
+// Used returns the labels of the registrations resolved so far, in first-use order.
+// Labels are the ones used in errors and graph output, e.g. *app.Database or "primary".
+func (rec *Recorder) Used() []string {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return append([]string(nil), rec.used...)
+}
+
+// Unused returns the labels of the registrations in the injector's graph that have not
+// been resolved so far, in graph order.
+func (rec *Recorder) Unused() []string {
+	nodes := rec.inj.Graph().Nodes
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	var unused []string
+	for _, node := range nodes {
+		if !rec.seen[node.Label] {
+			unused = append(unused, node.Label)
+		}
+	}
+	return unused
+}
@@ -0,0 +1,129 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Lazy defers the resolution of a dependency until Get is first called. Used as a factory
+// or Invoke parameter, it breaks expensive startup chains and lets two services depend on
+// each other; a Get that needs the service still being constructed fails with ErrCycle.
+// The resolved value is cached, so later calls return the same value; failures are retried.
+// A parameter of type func() (T, error) is a provider: it defers resolution the same way
+// but resolves again on every call, honoring the dependency's lifetime.
+// Usage: inj.Inject(func(db injector.Lazy[*Database]) *Reports { return &Reports{db: db} })
+type Lazy[T any] struct {
+	cell *lazyCell[T]
+}
+
+// lazyCell is the state shared by copies of a Lazy.
+type lazyCell[T any] struct {
+	mu      sync.Mutex
+	resolve func() (reflect.Value, error)
+	value   T
+	done    bool
+}
+
+// Get resolves the dependency on first use and returns the cached value afterwards.
+func (l Lazy[T]) Get() (T, error) {
+	var zero T
+	if l.cell == nil {
+		t := reflect.TypeOf((*T)(nil)).Elem()
+		return zero, &ResolveError{Kind: ErrNotFound, Type: t, msg: fmt.Sprintf("lazy %v was not created by an injector", t)}
+	}
+
+	l.cell.mu.Lock()
+	defer l.cell.mu.Unlock()
+
+	if l.cell.done {
+		return l.cell.value, nil
+	}
+	value, err := l.cell.resolve()
+	if err != nil {
+		return zero, err
+	}
+	// value is known to be assignable to T; a nil interface value stays the zero T
+	l.cell.value, _ = value.Interface().(T)
+	l.cell.done = true
+	return l.cell.value, nil
+}
+
+// MustGet is like Get but panics if the dependency cannot be resolved.
+func (l Lazy[T]) MustGet() T {
+	value, err := l.Get()
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// lazy is implemented by every Lazy[T] so the container can recognize and build them.
+type lazy interface {
+	lazyElem() reflect.Type
+	withResolver(resolve func() (reflect.Value, error)) interface{}
+}
+
+// lazyElem returns T.
+func (Lazy[T]) lazyElem() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// withResolver returns a Lazy that resolves its value with resolve.
+func (Lazy[T]) withResolver(resolve func() (reflect.Value, error)) interface{} {
+	return Lazy[T]{cell: &lazyCell[T]{resolve: resolve}}
+}
+
+var lazyType = reflect.TypeOf((*lazy)(nil)).Elem()
+
+// deferredElem returns T when t is a Lazy[T] or a func() (T, error) provider.
+func deferredElem(t reflect.Type) (reflect.Type, bool) {
+	switch {
+	case t.Kind() == reflect.Struct && t.Implements(lazyType):
+		return reflect.Zero(t).Interface().(lazy).lazyElem(), true
+	case t.Kind() == reflect.Func && t.NumIn() == 0 && t.NumOut() == 2 && t.Out(1) == errorType:
+		return t.Out(0), true
+	default:
+		return nil, false
+	}
+}
+
+// resolveDeferred builds the Lazy or provider function of type t, which resolves elem
+// from this container when it is used.
+func (i *Injector) resolveDeferred(t, elem reflect.Type) interface{} {
+	resolve := func() (reflect.Value, error) {
+		// Deferred resolution is not part of the construction that created the handle;
+		// using the handle before that construction ends fails on its construction lock
+		r := &resolution{}
+		inst, found, err := i.resolveType(r, elem)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if !found {
+			return reflect.Value{}, notFoundError(r, elem, "no dependency found for type %v", elem)
+		}
+
+		value := reflect.New(elem).Elem()
+		if v := reflect.ValueOf(inst); v.IsValid() {
+			if !v.Type().AssignableTo(elem) {
+				return reflect.Value{}, typeMismatchError(r, elem, "resolved type %v is not assignable to %v", v.Type(), elem)
+			}
+			value.Set(v)
+		}
+		return value, nil
+	}
+
+	if t.Kind() == reflect.Struct {
+		return reflect.Zero(t).Interface().(lazy).withResolver(resolve)
+	}
+
+	return reflect.MakeFunc(t, func([]reflect.Value) []reflect.Value {
+		err := reflect.New(errorType).Elem()
+		value, resolveErr := resolve()
+		if resolveErr != nil {
+			value = reflect.Zero(elem)
+			err.Set(reflect.ValueOf(resolveErr))
+		}
+		return []reflect.Value{value, err}
+	}).Interface()
+}
@@ -0,0 +1,146 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type EventBus struct {
+	Notifier Lazy[*Notifier]
+}
+
+type Notifier struct {
+	Bus *EventBus
+}
+
+func TestLazy_DefersResolution(t *testing.T) {
+	calls := 0
+	inj := NewInjector()
+	inj.Inject(func() *Database {
+		calls++
+		return &Database{Name: "db"}
+	})
+
+	var handle Lazy[*Database]
+	err := inj.Invoke(func(db Lazy[*Database]) { handle = db })
+	assert.NoError(t, err)
+	assert.Equal(t, 0, calls)
+
+	assert.Equal(t, "db", handle.MustGet().Name)
+	assert.Same(t, handle.MustGet(), Must[*Database](inj))
+	assert.Equal(t, 1, calls)
+}
+
+func TestLazy_CachesTransientValue(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(newRequestIDFactory(), Transient())
+
+	handle := Must[Lazy[*RequestID]](inj)
+	assert.Same(t, handle.MustGet(), handle.MustGet())
+}
+
+func TestLazy_BreaksConstructionCycle(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(func(n Lazy[*Notifier]) *EventBus { return &EventBus{Notifier: n} })
+	inj.Inject(func(bus *EventBus) *Notifier { return &Notifier{Bus: bus} })
+
+	assert.NoError(t, inj.Validate())
+
+	bus := Must[*EventBus](inj)
+	notifier, err := bus.Notifier.Get()
+	assert.NoError(t, err)
+	assert.Same(t, bus, notifier.Bus)
+	assert.Same(t, notifier, Must[*Notifier](inj))
+}
+
+func TestLazy_GetDuringConstructionReportsCycle(t *testing.T) {
+	var getErr error
+	inj := NewInjector()
+	inj.Inject(func(n Lazy[*Notifier]) *EventBus {
+		_, getErr = n.Get()
+		return &EventBus{Notifier: n}
+	})
+	inj.Inject(func(bus *EventBus) *Notifier { return &Notifier{Bus: bus} })
+
+	bus, err := Get[*EventBus](inj)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, getErr, ErrCycle)
+	assert.EqualError(t, getErr, "circular dependency detected: *injector.EventBus -> *injector.Notifier -> *injector.EventBus")
+
+	// Once the bus is built the handle resolves normally
+	notifier, err := bus.Notifier.Get()
+	assert.NoError(t, err)
+	assert.Same(t, bus, notifier.Bus)
+}
+
+func TestLazy_GetDuringScopedConstructionReportsCycle(t *testing.T) {
+	var getErr error
+	inj := NewInjector()
+	inj.Inject(func(n Lazy[*Notifier]) *EventBus {
+		_, getErr = n.Get()
+		return &EventBus{Notifier: n}
+	}, Scoped())
+	inj.Inject(func(bus *EventBus) *Notifier { return &Notifier{Bus: bus} }, Scoped())
+
+	_, err := Get[*EventBus](inj.NewScope())
+	assert.NoError(t, err)
+	assert.ErrorIs(t, getErr, ErrCycle)
+}
+
+func TestLazy_MissingDependency(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(func(db Lazy[*Database]) *UserRepository { return &UserRepository{} })
+
+	// Resolution succeeds since the dependency is only needed on first use
+	handle := Must[Lazy[*Database]](inj)
+	_, err := handle.Get()
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.ErrorIs(t, inj.Validate(), ErrNotFound)
+
+	// A late registration is picked up by the next Get
+	inj.Inject(&Database{Name: "late"})
+	assert.Equal(t, "late", handle.MustGet().Name)
+}
+
+func TestLazy_ZeroValue(t *testing.T) {
+	var handle Lazy[*Database]
+	_, err := handle.Get()
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Panics(t, func() { handle.MustGet() })
+}
+
+func TestProvider_ResolvesOnEveryCall(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(newRequestIDFactory(), Transient())
+	inj.Inject(&Database{Name: "db"})
+
+	err := inj.Invoke(func(nextID func() (*RequestID, error), db func() (*Database, error)) {
+		first, err := nextID()
+		assert.NoError(t, err)
+		second, err := nextID()
+		assert.NoError(t, err)
+		assert.NotSame(t, first, second)
+
+		d1, _ := db()
+		d2, _ := db()
+		assert.Same(t, d1, d2)
+	})
+	assert.NoError(t, err)
+}
+
+func TestProvider_ReturnsResolutionErrors(t *testing.T) {
+	inj := NewInjector()
+
+	provider := Must[func() (Storage, error)](inj)
+	storage, err := provider()
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, storage)
+
+	inj.Inject(&MemoryStorage{})
+	inj.Inject(func(s *MemoryStorage) Storage { return s })
+	storage, err = provider()
+	assert.NoError(t, err)
+	assert.IsType(t, &MemoryStorage{}, storage)
+}
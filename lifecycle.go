@@ -0,0 +1,266 @@
+package injector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// hook is a lifecycle callback attached to a registration.
+type hook func(ctx context.Context, instance interface{}) error
+
+// instance is a resolved dependency together with its registration and the container
+// it is cached in.
+type instance struct {
+	reg       *registration
+	value     interface{}
+	container *Injector
+}
+
+// OnStart attaches a hook that Start runs with the registration's instance.
+// Usage: inj.Inject(NewServer, injector.OnStart(func(ctx context.Context, s *Server) error { return s.Listen() }))
+func OnStart[T any](fn func(context.Context, T) error) Option {
+	return func(r *registration) {
+		r.onStart = append(r.onStart, typedHook(fn))
+	}
+}
+
+// OnStop attaches a hook that Stop runs with the registration's instance.
+// Usage: inj.Inject(NewServer, injector.OnStop(func(ctx context.Context, s *Server) error { return s.Shutdown(ctx) }))
+func OnStop[T any](fn func(context.Context, T) error) Option {
+	return func(r *registration) {
+		r.onStop = append(r.onStop, typedHook(fn))
+	}
+}
+
+// OnClose attaches a cleanup function that Close runs instead of io.Closer.Close.
+// Usage: inj.Inject(NewTempDir, injector.OnClose(func(d *TempDir) error { return os.RemoveAll(d.Path) }))
+func OnClose[T any](fn func(T) error) Option {
+	return func(r *registration) {
+		r.onClose = append(r.onClose, typedHook(func(_ context.Context, typed T) error {
+			return fn(typed)
+		}))
+	}
+}
+
+// typedHook adapts a typed hook to the instance stored in a registration.
+func typedHook[T any](fn func(context.Context, T) error) hook {
+	return func(ctx context.Context, value interface{}) error {
+		typed, ok := value.(T)
+		if !ok {
+			var zero T
+			return fmt.Errorf("%w: hook expects %T, got %T", ErrTypeMismatch, zero, value)
+		}
+		return fn(ctx, typed)
+	}
+}
+
+// record appends a cached instance to the construction log of this container.
+// Instances of a module's private container are recorded in the container the module
+// was installed in, so they are started and closed with it.
+func (i *Injector) record(reg *registration, value interface{}) {
+	owner := i
+	for owner.private {
+		owner = owner.parent
+	}
+
+	owner.mu.Lock()
+	defer owner.mu.Unlock()
+	owner.built = append(owner.built, instance{reg: reg, value: value, container: i})
+}
+
+// evict removes a constructed instance from the cache of its container, so the next
+// resolution constructs a new one. Caches already holding a newer registration are left alone.
+func (inst instance) evict() {
+	c, reg := inst.container, inst.reg
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case reg.lifetime == LifetimeScoped:
+		delete(c.scoped, reg)
+	case reg.group != "":
+		delete(c.groupValues, reg)
+	case reg.named:
+		if c.nameRegistrations[reg.name] == reg {
+			delete(c.dependencies, reg.name)
+		}
+	default:
+		if c.typeRegistrations[reg.typ] == reg {
+			c.typeRegistry[reg.typ] = reg.factory.Interface()
+		}
+	}
+}
+
+// Start resolves every registration of this container that has lifecycle hooks and
+// runs their OnStart hooks in dependency order: a dependency always starts before the
+// services built from it. If a hook fails, or ctx is done, the services that already
+// started are stopped in reverse order and all errors are returned joined. The rollback
+// ignores the cancellation of ctx, so a cancelled Start never leaves services running.
+func (i *Injector) Start(ctx context.Context) error {
+	i.lifecycle.Lock()
+	defer i.lifecycle.Unlock()
+
+	if len(i.started) > 0 {
+		return errors.New("injector already started")
+	}
+
+	ordered, err := i.lifecycleOrder()
+	if err != nil {
+		return err
+	}
+
+	rollback := context.WithoutCancel(ctx)
+	for _, inst := range ordered {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(err, i.stopStarted(rollback))
+		}
+		for _, fn := range inst.reg.onStart {
+			if err := fn(ctx, inst.value); err != nil {
+				startErr := fmt.Errorf("start %s: %w", inst.reg.label, err)
+				// The failing service is not considered started, so only earlier ones are stopped
+				return errors.Join(startErr, i.stopStarted(rollback))
+			}
+		}
+		i.started = append(i.started, inst)
+	}
+	return nil
+}
+
+// Stop runs the OnStop hooks of the services started by Start, in reverse start order.
+// Every hook runs even if an earlier one fails; if ctx is done, the remaining services
+// are left started so a later Stop can stop them. All errors are returned joined.
+func (i *Injector) Stop(ctx context.Context) error {
+	i.lifecycle.Lock()
+	defer i.lifecycle.Unlock()
+
+	return i.stopStarted(ctx)
+}
+
+// stopStarted stops started services in reverse order. Callers must hold i.lifecycle.
+func (i *Injector) stopStarted(ctx context.Context) error {
+	var errs []error
+	for len(i.started) > 0 {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("stop: %w", err))
+			break
+		}
+		inst := i.started[len(i.started)-1]
+		i.started = i.started[:len(i.started)-1]
+		for _, fn := range inst.reg.onStop {
+			if err := fn(ctx, inst.value); err != nil {
+				errs = append(errs, fmt.Errorf("stop %s: %w", inst.reg.label, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// lifecycleOrder resolves the registrations with hooks and returns their instances in
+// dependency order. Registered instances have no dependencies of their own, so they come
+// first, followed by constructed singletons in the order their construction completed.
+// Transient registrations have no instance to start, so hooks on them are an error.
+func (i *Injector) lifecycleOrder() ([]instance, error) {
+	var ordered []instance
+	hooked := make(map[*registration]bool)
+	for _, c := range i.withPrivates() {
+		for _, reg := range c.currentRegistrations() {
+			if len(reg.onStart) == 0 && len(reg.onStop) == 0 {
+				continue
+			}
+			if reg.lifetime == LifetimeTransient && reg.isFactory() {
+				return nil, fmt.Errorf("%s: lifecycle hooks require a singleton or scoped lifetime, got %v", reg.label, reg.lifetime)
+			}
+			hooked[reg] = true
+
+			value, err := c.resolveRegistration(&resolution{}, reg)
+			if err != nil {
+				return nil, err
+			}
+			if !reg.isFactory() {
+				ordered = append(ordered, instance{reg: reg, value: value})
+			}
+		}
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	// A registration evicted by an override restore was constructed again; start the latest
+	latest := make(map[*registration]int)
+	for idx, inst := range i.built {
+		latest[inst.reg] = idx
+	}
+	for idx, inst := range i.built {
+		if hooked[inst.reg] && latest[inst.reg] == idx {
+			ordered = append(ordered, inst)
+		}
+	}
+	return ordered, nil
+}
+
+// resolveRegistration resolves the instance of one of this container's registrations.
+func (i *Injector) resolveRegistration(r *resolution, reg *registration) (interface{}, error) {
+	if reg.group != "" {
+		return i.resolveGroupMember(r, i, reg)
+	}
+	if reg.named {
+		return i.resolveName(r, reg.name)
+	}
+	return i.resolveRegisteredDependency(r, i, reg.typ)
+}
+
+// Close releases every instance this container constructed, in reverse construction
+// order, so services are closed before the dependencies they were built from.
+// Instances with OnClose cleanup functions run those; otherwise instances implementing
+// io.Closer are closed. Instances registered directly are owned by the caller and left
+// alone. All errors are returned joined with errors.Join.
+//
+// Closed instances are evicted from the container's caches, so resolving them again after
+// Close constructs new ones. Closing a scope releases the scoped instances cached in it;
+// singletons stay cached in the container they were registered in until that container
+// is closed.
+func (i *Injector) Close() error {
+	i.mu.Lock()
+	built := i.built
+	i.built = nil
+	i.scoped = make(map[*registration]interface{})
+	i.mu.Unlock()
+
+	for _, inst := range built {
+		inst.evict()
+	}
+
+	var errs []error
+	closed := make(map[interface{}]bool)
+	for idx := len(built) - 1; idx >= 0; idx-- {
+		inst := built[idx]
+		// The same instance can be cached under several registrations
+		if inst.value == nil {
+			continue
+		}
+		// An interface field can make a comparable type unhashable, so check the value
+		if reflect.ValueOf(inst.value).Comparable() {
+			if closed[inst.value] {
+				continue
+			}
+			closed[inst.value] = true
+		}
+
+		if len(inst.reg.onClose) > 0 {
+			for _, fn := range inst.reg.onClose {
+				if err := fn(context.Background(), inst.value); err != nil {
+					errs = append(errs, fmt.Errorf("close %s: %w", inst.reg.label, err))
+				}
+			}
+			continue
+		}
+		if closer, ok := inst.value.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("close %s: %w", inst.reg.label, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
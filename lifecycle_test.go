@@ -0,0 +1,358 @@
+package injector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Cache struct {
+	DB *Database
+}
+
+type Server struct {
+	DB    *Database
+	Cache *Cache
+}
+
+// lifecycleRecorder collects the order in which hooks run.
+type lifecycleRecorder struct {
+	events []string
+}
+
+func (rec *lifecycleRecorder) hooks(name string) []Option {
+	return []Option{
+		OnStart(func(ctx context.Context, _ interface{}) error {
+			rec.events = append(rec.events, "start "+name)
+			return nil
+		}),
+		OnStop(func(ctx context.Context, _ interface{}) error {
+			rec.events = append(rec.events, "stop "+name)
+			return nil
+		}),
+	}
+}
+
+func TestLifecycle_StartAndStopInDependencyOrder(t *testing.T) {
+	rec := &lifecycleRecorder{}
+	inj := NewInjector()
+
+	// Registered in reverse dependency order on purpose
+	inj.Inject(func(db *Database, cache *Cache) *Server { return &Server{DB: db, Cache: cache} }, rec.hooks("server")...)
+	inj.Inject(func(db *Database) *Cache { return &Cache{DB: db} }, rec.hooks("cache")...)
+	inj.Inject(NewDB, rec.hooks("db")...)
+
+	assert.NoError(t, inj.Start(context.Background()))
+	assert.Equal(t, []string{"start db", "start cache", "start server"}, rec.events)
+
+	rec.events = nil
+	assert.NoError(t, inj.Stop(context.Background()))
+	assert.Equal(t, []string{"stop server", "stop cache", "stop db"}, rec.events)
+}
+
+func TestLifecycle_TypedHooksReceiveInstance(t *testing.T) {
+	inj := NewInjector()
+	var started *Database
+	inj.Inject(NewDB, OnStart(func(ctx context.Context, db *Database) error {
+		started = db
+		return nil
+	}))
+
+	assert.NoError(t, inj.Start(context.Background()))
+	assert.Same(t, Must[*Database](inj), started)
+}
+
+func TestLifecycle_InstancesAndNamedRegistrations(t *testing.T) {
+	rec := &lifecycleRecorder{}
+	inj := NewInjector()
+	inj.InjectByName(func(db *Database) *Cache { return &Cache{DB: db} }, "cache", rec.hooks("cache")...)
+	inj.Inject(&Database{Name: "db"}, rec.hooks("db")...)
+
+	assert.NoError(t, inj.Start(context.Background()))
+	assert.NoError(t, inj.Stop(context.Background()))
+	assert.Equal(t, []string{"start db", "start cache", "stop cache", "stop db"}, rec.events)
+}
+
+func TestLifecycle_StartFailureStopsStartedServices(t *testing.T) {
+	errListen := errors.New("address in use")
+	rec := &lifecycleRecorder{}
+	inj := NewInjector()
+	inj.Inject(NewDB, rec.hooks("db")...)
+	inj.Inject(func(db *Database) *Server { return &Server{DB: db} },
+		OnStart(func(ctx context.Context, s *Server) error { return errListen }),
+		OnStop(func(ctx context.Context, s *Server) error {
+			rec.events = append(rec.events, "stop server")
+			return nil
+		}),
+	)
+
+	err := inj.Start(context.Background())
+	assert.ErrorIs(t, err, errListen)
+	assert.Contains(t, err.Error(), "start *injector.Server")
+	assert.Equal(t, []string{"start db", "stop db"}, rec.events)
+
+	// Nothing is left to stop
+	rec.events = nil
+	assert.NoError(t, inj.Stop(context.Background()))
+	assert.Empty(t, rec.events)
+}
+
+func TestLifecycle_StopAggregatesErrors(t *testing.T) {
+	errDB := errors.New("db close failed")
+	errCache := errors.New("cache close failed")
+	inj := NewInjector()
+	inj.Inject(NewDB, OnStop(func(ctx context.Context, db *Database) error { return errDB }))
+	inj.Inject(func(db *Database) *Cache { return &Cache{DB: db} },
+		OnStop(func(ctx context.Context, c *Cache) error { return errCache }))
+
+	assert.NoError(t, inj.Start(context.Background()))
+	err := inj.Stop(context.Background())
+	assert.ErrorIs(t, err, errDB)
+	assert.ErrorIs(t, err, errCache)
+}
+
+func TestLifecycle_ContextDeadline(t *testing.T) {
+	rec := &lifecycleRecorder{}
+	inj := NewInjector()
+	inj.Inject(NewDB, rec.hooks("db")...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := inj.Start(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, rec.events)
+
+	assert.NoError(t, inj.Start(context.Background()))
+	err = inj.Stop(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"start db"}, rec.events)
+
+	// Services skipped by a cancelled Stop are still stopped later
+	assert.NoError(t, inj.Stop(context.Background()))
+	assert.Equal(t, []string{"start db", "stop db"}, rec.events)
+}
+
+func TestLifecycle_CancelledDuringStart(t *testing.T) {
+	rec := &lifecycleRecorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	inj := NewInjector()
+	inj.Inject(NewDB, append(rec.hooks("db"), OnStart(func(ctx context.Context, db *Database) error {
+		cancel()
+		return nil
+	}))...)
+	inj.Inject(func(db *Database) *Cache { return &Cache{DB: db} }, rec.hooks("cache")...)
+
+	err := inj.Start(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"start db", "stop db"}, rec.events)
+
+	// Nothing is left to stop
+	rec.events = nil
+	assert.NoError(t, inj.Stop(context.Background()))
+	assert.Empty(t, rec.events)
+}
+
+func TestLifecycle_HookFailsWithCancelledContext(t *testing.T) {
+	rec := &lifecycleRecorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	inj := NewInjector()
+	inj.Inject(NewDB, rec.hooks("db")...)
+	inj.Inject(func(db *Database) *Cache { return &Cache{DB: db} },
+		OnStart(func(ctx context.Context, c *Cache) error {
+			cancel()
+			return ctx.Err()
+		}))
+
+	err := inj.Start(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"start db", "stop db"}, rec.events)
+}
+
+func TestLifecycle_ResolutionFailure(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewUserRepository, OnStart(func(ctx context.Context, r *UserRepository) error { return nil }))
+
+	err := inj.Start(context.Background())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLifecycle_StartTwice(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB, OnStart(func(ctx context.Context, db *Database) error { return nil }))
+
+	assert.NoError(t, inj.Start(context.Background()))
+	assert.Error(t, inj.Start(context.Background()))
+	assert.NoError(t, inj.Stop(context.Background()))
+	assert.NoError(t, inj.Start(context.Background()))
+}
+
+func TestLifecycle_TransientHooksRejected(t *testing.T) {
+	rec := &lifecycleRecorder{}
+	inj := NewInjector()
+	inj.Inject(NewDB, append(rec.hooks("db"), Transient())...)
+
+	err := inj.Start(context.Background())
+	assert.EqualError(t, err, "*injector.Database: lifecycle hooks require a singleton or scoped lifetime, got transient")
+	assert.Empty(t, rec.events)
+}
+
+func TestLifecycle_ScopedHooksStartContainerInstance(t *testing.T) {
+	var started *Database
+	inj := NewInjector()
+	inj.Inject(NewDB, Scoped(), OnStart(func(ctx context.Context, db *Database) error {
+		started = db
+		return nil
+	}))
+
+	assert.NoError(t, inj.Start(context.Background()))
+	assert.Same(t, Must[*Database](inj), started)
+}
+
+func TestLifecycle_HookTypeMismatch(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB, OnStart(func(ctx context.Context, c *Cache) error { return nil }))
+
+	err := inj.Start(context.Background())
+	assert.ErrorIs(t, err, ErrTypeMismatch)
+}
+
+// closeRecorder is an io.Closer that records when it is closed.
+type closeRecorder struct {
+	name   string
+	events *[]string
+	err    error
+}
+
+func (c *closeRecorder) Close() error {
+	*c.events = append(*c.events, "close "+c.name)
+	return c.err
+}
+
+type closerDependent struct {
+	*closeRecorder
+	Dep *closeRecorder
+}
+
+func TestClose_ClosersInReverseConstructionOrder(t *testing.T) {
+	var events []string
+	inj := NewInjector()
+	inj.Inject(func(dep *closeRecorder) *closerDependent {
+		return &closerDependent{closeRecorder: &closeRecorder{name: "dependent", events: &events}, Dep: dep}
+	})
+	inj.Inject(func() *closeRecorder { return &closeRecorder{name: "handle", events: &events} })
+
+	Must[*closerDependent](inj)
+
+	assert.NoError(t, inj.Close())
+	assert.Equal(t, []string{"close dependent", "close handle"}, events)
+
+	// Closing again does nothing
+	events = nil
+	assert.NoError(t, inj.Close())
+	assert.Empty(t, events)
+}
+
+func TestClose_CleanupFuncAndErrors(t *testing.T) {
+	var events []string
+	errClose := errors.New("close failed")
+	errCleanup := errors.New("cleanup failed")
+
+	inj := NewInjector()
+	inj.Inject(func() *closeRecorder { return &closeRecorder{name: "handle", events: &events, err: errClose} })
+	inj.InjectByName(NewDB, "tempDB", OnClose(func(db *Database) error {
+		events = append(events, "cleanup "+db.Name)
+		return errCleanup
+	}))
+
+	Must[*closeRecorder](inj)
+	inj.MustResolve("tempDB")
+
+	err := inj.Close()
+	assert.ErrorIs(t, err, errClose)
+	assert.ErrorIs(t, err, errCleanup)
+	assert.Equal(t, []string{"cleanup db", "close handle"}, events)
+}
+
+func TestClose_SkipsUnresolvedAndRegisteredInstances(t *testing.T) {
+	var events []string
+	inj := NewInjector()
+	inj.Inject(&closeRecorder{name: "instance", events: &events})
+	inj.InjectByName(func() *closeRecorder { return &closeRecorder{name: "lazy", events: &events} }, "lazy")
+
+	Must[*closeRecorder](inj)
+
+	assert.NoError(t, inj.Close())
+	assert.Empty(t, events)
+}
+
+// holder is comparable by type, but not hashable when V holds a slice.
+type holder struct {
+	V interface{}
+}
+
+func TestClose_UnhashableValue(t *testing.T) {
+	closed := false
+	inj := NewInjector()
+	inj.Inject(func() holder { return holder{V: []int{1}} }, OnClose(func(h holder) error {
+		closed = true
+		return nil
+	}))
+	Must[holder](inj)
+
+	assert.NotPanics(t, func() { assert.NoError(t, inj.Close()) })
+	assert.True(t, closed)
+}
+
+func TestClose_EvictsClosedInstances(t *testing.T) {
+	var events []string
+	inj := NewInjector()
+	inj.Inject(func() *closeRecorder { return &closeRecorder{name: "typed", events: &events} })
+	inj.InjectByName(func() *closeRecorder { return &closeRecorder{name: "named", events: &events} }, "named")
+	inj.InjectGroup("recorders", func() *closeRecorder { return &closeRecorder{name: "member", events: &events} })
+
+	typed := Must[*closeRecorder](inj)
+	named := inj.MustResolve("named")
+	members, err := Group[*closeRecorder](inj, "recorders")
+	assert.NoError(t, err)
+
+	assert.NoError(t, inj.Close())
+	assert.ElementsMatch(t, []string{"close typed", "close named", "close member"}, events)
+
+	// Closed instances are never handed out again
+	assert.NotSame(t, typed, Must[*closeRecorder](inj))
+	assert.NotSame(t, named, inj.MustResolve("named"))
+	again, err := Group[*closeRecorder](inj, "recorders")
+	assert.NoError(t, err)
+	assert.NotSame(t, members[0], again[0])
+
+	// The new instances are closed by the next Close
+	events = nil
+	assert.NoError(t, inj.Close())
+	assert.Len(t, events, 3)
+}
+
+func TestClose_ScopeReleasesScopedInstances(t *testing.T) {
+	var events []string
+	inj := NewInjector()
+	inj.Inject(func() *closeRecorder { return &closeRecorder{name: "scoped", events: &events} }, Scoped())
+	inj.InjectByName(func() *closerDependent {
+		return &closerDependent{closeRecorder: &closeRecorder{name: "singleton", events: &events}}
+	}, "singleton")
+
+	scope := inj.NewScope()
+	first := Must[*closeRecorder](scope)
+	scope.MustResolve("singleton")
+
+	assert.NoError(t, scope.Close())
+	assert.Equal(t, []string{"close scoped"}, events)
+
+	// The scope builds a fresh instance after it was closed
+	assert.NotSame(t, first, Must[*closeRecorder](scope))
+
+	events = nil
+	assert.NoError(t, inj.Close())
+	assert.Equal(t, []string{"close singleton"}, events)
+}
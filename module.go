@@ -0,0 +1,291 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Module bundles the registrations of one package so they can be installed together.
+// Usage:
+//
+//	var DatabaseModule = injector.Module{
+//		Name:      "database",
+//		Providers: []injector.Provider{injector.Provide(NewDB), injector.Provide(NewUserRepository)},
+//		Includes:  []injector.Module{ConfigModule},
+//	}
+type Module struct {
+	// Name identifies the module in errors and graph output. It is required and must be
+	// unique within a container.
+	Name string
+	// Providers are registered when the module is installed. Providers marked Private
+	// are only visible to the module itself.
+	Providers []Provider
+	// Decorators are registered like Decorate; each one decorates the type of its first parameter.
+	Decorators []interface{}
+	// Invokes are called like Invoke once every module of the Install call is registered.
+	// They can resolve the module's private providers.
+	Invokes []interface{}
+	// Includes are installed before this module.
+	Includes []Module
+}
+
+// Provider is a registration made by a Module, built with Provide, ProvideNamed or ProvideGroup.
+type Provider struct {
+	dependency interface{}
+	name       string
+	group      string
+	private    bool
+	opts       []Option
+}
+
+// Private returns a copy of the provider that only its module can resolve: the module's
+// other providers, decorators and invokes see it, but For[T], Invoke and the providers
+// of other modules do not. It keeps internal helpers out of the container's type registry.
+// Usage: injector.Provide(NewConnectionPool).Private()
+func (p Provider) Private() Provider {
+	p.private = true
+	return p
+}
+
+// Provide registers dependency by its type, like Inject.
+func Provide(dependency interface{}, opts ...Option) Provider {
+	return Provider{dependency: dependency, opts: opts}
+}
+
+// ProvideNamed registers dependency with a name, like InjectByName.
+func ProvideNamed(dependency interface{}, name string, opts ...Option) Provider {
+	return Provider{dependency: dependency, name: name, opts: opts}
+}
+
+// ProvideGroup adds dependency to a group, like InjectGroup.
+func ProvideGroup(group string, dependency interface{}, opts ...Option) Provider {
+	return Provider{dependency: dependency, group: group, opts: opts}
+}
+
+// register makes the registration for a module in i, which is the module's private
+// container for private providers. Public factories resolve their parameters from
+// resolver, if set. The caller must hold i.mu.
+func (p Provider) register(i *Injector, module string, resolver *Injector) {
+	opts := append(append([]Option(nil), p.opts...), func(r *registration) {
+		r.module = module
+		r.private = p.private
+		if !p.private {
+			r.resolver = resolver
+		}
+	})
+
+	switch {
+	case p.name != "":
+		i.injectName(p.dependency, p.name, opts)
+	case p.group != "":
+		i.injectGroup(p.group, p.dependency, opts)
+	default:
+		i.injectType(p.dependency, opts)
+	}
+}
+
+// Install registers the providers and decorators of the given modules and their
+// includes, includes first, then calls their invokes in the same order.
+// An include shared by several modules, or already installed on this container, is
+// installed once. Install returns an error without registering anything if a module has
+// no name, a provider is nil, a decorator is invalid, or a module is passed twice or was
+// passed to an earlier Install on this container. An error returned by an invoke is
+// wrapped with the module name; registrations made before it are kept.
+// Usage: err := inj.Install(DatabaseModule, HTTPModule)
+func (i *Injector) Install(modules ...Module) error {
+	ordered, err := flattenModules(modules)
+	if err != nil {
+		return err
+	}
+
+	decorators := make(map[*Module][]reflect.Value, len(ordered))
+	for _, m := range ordered {
+		for _, p := range m.Providers {
+			if p.dependency == nil {
+				return fmt.Errorf("module %q: provider is nil", m.Name)
+			}
+		}
+		for _, decorator := range m.Decorators {
+			fn := reflect.ValueOf(decorator)
+			if fn.Kind() != reflect.Func || fn.Type().NumIn() == 0 {
+				return fmt.Errorf("module %q: decorator must be a function taking the decorated value, got %T", m.Name, decorator)
+			}
+			fn, err := checkDecorator(fn.Type().In(0), decorator)
+			if err != nil {
+				return fmt.Errorf("module %q: %w", m.Name, err)
+			}
+			decorators[m] = append(decorators[m], fn)
+		}
+	}
+
+	containers, err := i.registerModules(modules, ordered, decorators)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range ordered {
+		c, ok := containers[m]
+		if !ok {
+			continue
+		}
+		for _, invoke := range m.Invokes {
+			if err := c.Invoke(invoke); err != nil {
+				return fmt.Errorf("module %q: %w", m.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// registerModules records the modules as installed and makes their registrations,
+// skipping includes an earlier Install already made.
+// It returns the container each registered module resolves from: a private container,
+// child of this one, for modules with private providers, and this container otherwise.
+func (i *Injector) registerModules(modules []Module, ordered []*Module, decorators map[*Module][]reflect.Value) (map[*Module]*Injector, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, m := range modules {
+		if i.modules[m.Name] != nil {
+			return nil, fmt.Errorf("module %q is already installed", m.Name)
+		}
+	}
+	for _, m := range ordered {
+		if installed := i.modules[m.Name]; installed != nil && !sameModule(installed, m) {
+			return nil, fmt.Errorf("module %q is already installed with different contents", m.Name)
+		}
+	}
+
+	containers := make(map[*Module]*Injector, len(ordered))
+	for _, m := range ordered {
+		if i.modules[m.Name] != nil {
+			continue
+		}
+		installed := *m
+		i.modules[m.Name] = &installed
+		containers[m] = i
+
+		var private *Injector
+		for _, p := range m.Providers {
+			if !p.private {
+				continue
+			}
+			if private == nil {
+				private = newContainer(i)
+				private.private = true
+				i.privates = append(i.privates, private)
+				containers[m] = private
+			}
+			private.mu.Lock()
+			p.register(private, m.Name, nil)
+			private.mu.Unlock()
+		}
+
+		for _, p := range m.Providers {
+			if !p.private {
+				p.register(i, m.Name, private)
+			}
+		}
+		for _, fn := range decorators[m] {
+			t := fn.Type().In(0)
+			i.decorators[t] = append(i.decorators[t], fn)
+		}
+	}
+	return containers, nil
+}
+
+// flattenModules returns the modules and their includes, includes first. Every module
+// must be named and the given modules must be distinct; a module reached again, such
+// as an include shared by two modules, is returned once. Two different modules with
+// the same name are an error.
+func flattenModules(modules []Module) ([]*Module, error) {
+	top := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		if m.Name != "" && top[m.Name] {
+			return nil, fmt.Errorf("module %q is installed twice", m.Name)
+		}
+		top[m.Name] = true
+	}
+
+	var ordered []*Module
+	seen := make(map[string]*Module)
+
+	var visit func(m *Module) error
+	visit = func(m *Module) error {
+		if m.Name == "" {
+			return fmt.Errorf("module has no name")
+		}
+		if prev := seen[m.Name]; prev != nil {
+			if !sameModule(prev, m) {
+				return fmt.Errorf("module %q is defined twice with different contents", m.Name)
+			}
+			return nil
+		}
+		seen[m.Name] = m
+
+		for idx := range m.Includes {
+			if err := visit(&m.Includes[idx]); err != nil {
+				return fmt.Errorf("module %q: %w", m.Name, err)
+			}
+		}
+		ordered = append(ordered, m)
+		return nil
+	}
+
+	for idx := range modules {
+		if err := visit(&modules[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// sameModule reports whether a and b are the same module: they have the same name and
+// the same providers, decorators, invokes and includes, in the same order.
+func sameModule(a, b *Module) bool {
+	if a == b {
+		return true
+	}
+	if a.Name != b.Name || len(a.Providers) != len(b.Providers) || len(a.Decorators) != len(b.Decorators) ||
+		len(a.Invokes) != len(b.Invokes) || len(a.Includes) != len(b.Includes) {
+		return false
+	}
+	for idx, pa := range a.Providers {
+		pb := b.Providers[idx]
+		if pa.name != pb.name || pa.group != pb.group || pa.private != pb.private ||
+			!sameValue(pa.dependency, pb.dependency) || len(pa.opts) != len(pb.opts) {
+			return false
+		}
+		for o := range pa.opts {
+			if !sameValue(pa.opts[o], pb.opts[o]) {
+				return false
+			}
+		}
+	}
+	for idx := range a.Decorators {
+		if !sameValue(a.Decorators[idx], b.Decorators[idx]) {
+			return false
+		}
+	}
+	for idx := range a.Invokes {
+		if !sameValue(a.Invokes[idx], b.Invokes[idx]) {
+			return false
+		}
+	}
+	for idx := range a.Includes {
+		if !sameModule(&a.Includes[idx], &b.Includes[idx]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameValue reports whether two module entries are the same. Functions are compared by
+// their code, everything else with reflect.DeepEqual.
+func sameValue(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.IsValid() && vb.IsValid() && va.Kind() == reflect.Func && vb.Kind() == reflect.Func {
+		return va.Type() == vb.Type() && va.Pointer() == vb.Pointer()
+	}
+	return reflect.DeepEqual(a, b)
+}
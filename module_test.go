@@ -0,0 +1,278 @@
+package injector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var databaseModule = Module{
+	Name:      "database",
+	Providers: []Provider{Provide(NewDB), ProvideNamed(&Database{Name: "replica"}, "replica")},
+}
+
+var repositoryModule = Module{
+	Name:      "repository",
+	Providers: []Provider{Provide(NewUserRepository), Provide(NewUserService, Transient())},
+	Includes:  []Module{databaseModule},
+}
+
+func TestInstall_RegistersProvidersAndIncludes(t *testing.T) {
+	inj := NewInjector()
+	assert.NoError(t, inj.Install(repositoryModule))
+
+	svc := Must[*UserService](inj)
+	assert.Equal(t, "db", svc.Repo.DB.Name)
+	assert.NotSame(t, svc, Must[*UserService](inj))
+	assert.Equal(t, "replica", inj.MustResolve("replica").(*Database).Name)
+	assert.NoError(t, inj.Validate())
+}
+
+func TestInstall_DecoratorsAndInvokes(t *testing.T) {
+	var invoked []string
+	inj := NewInjector()
+	err := inj.Install(Module{
+		Name:       "app",
+		Includes:   []Module{databaseModule},
+		Providers:  []Provider{ProvideGroup("health", &staticChecker{Name: "disk"})},
+		Decorators: []interface{}{func(db *Database) *Database { return &Database{Name: db.Name + "-decorated"} }},
+		Invokes: []interface{}{
+			func(db *Database, checks []HealthChecker) {
+				invoked = append(invoked, db.Name)
+				assert.Len(t, checks, 1)
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"db-decorated"}, invoked)
+}
+
+func TestInstall_DuplicateModules(t *testing.T) {
+	inj := NewInjector()
+	assert.NoError(t, inj.Install(databaseModule))
+
+	err := inj.Install(databaseModule)
+	assert.EqualError(t, err, `module "database" is already installed`)
+
+	// Passed twice within one call
+	err = NewInjector().Install(databaseModule, databaseModule)
+	assert.EqualError(t, err, `module "database" is installed twice`)
+
+	// Scopes may install a module their parent already installed
+	assert.NoError(t, inj.NewScope().Install(databaseModule))
+}
+
+func TestInstall_SharedIncludes(t *testing.T) {
+	var invoked int
+	configModule := Module{
+		Name:      "config",
+		Providers: []Provider{Provide(NewDB)},
+		Invokes:   []interface{}{func(db *Database) { invoked++ }},
+	}
+	repoModule := Module{Name: "repo", Providers: []Provider{Provide(NewUserRepository)}, Includes: []Module{configModule}}
+	serviceModule := Module{Name: "service", Providers: []Provider{Provide(NewUserService)}, Includes: []Module{configModule}}
+
+	inj := NewInjector()
+	assert.NoError(t, inj.Install(repoModule, serviceModule))
+	assert.Equal(t, 1, invoked)
+	assert.Equal(t, "db", Must[*UserService](inj).Repo.DB.Name)
+
+	// An include installed by an earlier call is already satisfied
+	statsModule := Module{Name: "stats", Includes: []Module{configModule}}
+	assert.NoError(t, inj.Install(statsModule))
+	assert.Equal(t, 1, invoked)
+
+	// A module already reached through an include is installed once
+	assert.NoError(t, NewInjector().Install(repositoryModule, databaseModule))
+}
+
+func TestInstall_SameNameDifferentModules(t *testing.T) {
+	dbConfig := Module{Name: "config", Providers: []Provider{Provide(NewDB)}}
+	cacheConfig := Module{Name: "config", Providers: []Provider{Provide(func() *Cache { return &Cache{} })}}
+	a := Module{Name: "a", Includes: []Module{dbConfig}}
+	b := Module{Name: "b", Includes: []Module{cacheConfig}}
+
+	inj := NewInjector()
+	err := inj.Install(a, b)
+	assert.EqualError(t, err, `module "b": module "config" is defined twice with different contents`)
+	_, err = Get[*Database](inj)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Also against a module installed by an earlier call
+	assert.NoError(t, inj.Install(a))
+	err = inj.Install(b)
+	assert.EqualError(t, err, `module "config" is already installed with different contents`)
+	_, err = Get[*Cache](inj)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Equal instance providers are the same module
+	shared := func() Module {
+		return Module{Name: "shared", Providers: []Provider{Provide(&Database{Name: "db"}), ProvideNamed([]string{"x"}, "hosts")}}
+	}
+	assert.NoError(t, NewInjector().Install(Module{Name: "c", Includes: []Module{shared()}}, Module{Name: "d", Includes: []Module{shared()}}))
+}
+
+func TestInstall_InvalidModulesRegisterNothing(t *testing.T) {
+	inj := NewInjector()
+
+	err := inj.Install(Module{Name: "app", Includes: []Module{{Providers: []Provider{Provide(NewDB)}}}})
+	assert.EqualError(t, err, `module "app": module has no name`)
+
+	err = inj.Install(Module{Name: "app", Providers: []Provider{Provide(NewDB), Provide(nil)}})
+	assert.EqualError(t, err, `module "app": provider is nil`)
+
+	err = inj.Install(Module{Name: "app", Providers: []Provider{Provide(NewDB)}, Decorators: []interface{}{func() {}}})
+	assert.ErrorContains(t, err, `module "app": decorator must be a function`)
+
+	err = inj.Install(Module{Name: "app", Providers: []Provider{Provide(NewDB)}, Decorators: []interface{}{func(db *Database) {}}})
+	assert.ErrorContains(t, err, `module "app": decorator for *injector.Database must be`)
+
+	_, err = Get[*Database](inj)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// A rejected module can be installed once fixed
+	assert.NoError(t, inj.Install(databaseModule))
+}
+
+func TestInstall_ErrorsNameTheModule(t *testing.T) {
+	inj := NewInjector()
+	err := inj.Install(Module{
+		Name:      "repository",
+		Providers: []Provider{Provide(NewUserRepository)},
+		Invokes:   []interface{}{func(repo *UserRepository) {}},
+	})
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, `module "repository": no dependency found for parameter type *injector.Database (resolving *injector.UserRepository (module "repository"))`)
+
+	err = NewInjector().Install(Module{
+		Name:    "failing",
+		Invokes: []interface{}{func() error { return errors.New("boom") }},
+	})
+	assert.EqualError(t, err, `module "failing": boom`)
+}
+
+func TestInstall_GraphShowsModules(t *testing.T) {
+	inj := NewInjector()
+	assert.NoError(t, inj.Install(repositoryModule))
+	inj.Inject(&RequestContext{})
+
+	g := inj.Graph()
+	modules := make(map[string]string)
+	for _, node := range g.Nodes {
+		modules[node.Label] = node.Module
+	}
+	assert.Equal(t, "database", modules[`*injector.Database (module "database")`])
+	assert.Equal(t, "repository", modules[`*injector.UserService (module "repository")`])
+	assert.Equal(t, "", modules["*injector.RequestContext"])
+	assert.Contains(t, g.DOT(), `label="*injector.UserRepository (module \"repository\")\nsingleton"`)
+}
+
+func TestInstall_OutProvidersKeepModule(t *testing.T) {
+	calls := 0
+	inj := NewInjector()
+	assert.NoError(t, inj.Install(Module{Name: "databases", Providers: []Provider{Provide(newDatabases(&calls))}}))
+
+	for _, node := range inj.Graph().Nodes {
+		assert.Equal(t, "databases", node.Module, node.Label)
+	}
+}
+
+func newCacheModule(name, dbName string) Module {
+	return Module{
+		Name: name,
+		Providers: []Provider{
+			Provide(&Database{Name: dbName}).Private(),
+			Provide(func(db *Database) *Cache { return &Cache{DB: db} }).Private(),
+			ProvideNamed(func(c *Cache) *Server { return &Server{DB: c.DB, Cache: c} }, name),
+		},
+	}
+}
+
+func TestInstall_PrivateProviders(t *testing.T) {
+	inj := NewInjector()
+	assert.NoError(t, inj.Install(newCacheModule("sessions", "redis")))
+
+	server := inj.MustResolve("sessions").(*Server)
+	assert.Equal(t, "redis", server.DB.Name)
+
+	_, err := Get[*Database](inj)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = Get[Cache](inj)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, inj.Invoke(func(c *Cache) {}), ErrNotFound)
+	assert.NoError(t, inj.Validate())
+}
+
+func TestInstall_PrivateProvidersDoNotCollide(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "public"})
+	assert.NoError(t, inj.Install(newCacheModule("sessions", "redis"), newCacheModule("pages", "memcached")))
+
+	assert.Equal(t, "redis", inj.MustResolve("sessions").(*Server).DB.Name)
+	assert.Equal(t, "memcached", inj.MustResolve("pages").(*Server).DB.Name)
+	assert.Equal(t, "public", Must[*Database](inj).Name)
+}
+
+func TestInstall_PrivateProvidersVisibleToModuleInvokesAndDecorators(t *testing.T) {
+	inj := NewInjector()
+	m := newCacheModule("sessions", "redis")
+	m.Decorators = []interface{}{func(s *Server, c *Cache) *Server { return &Server{DB: &Database{Name: "decorated"}, Cache: c} }}
+	m.Invokes = []interface{}{func(c *Cache) { assert.Equal(t, "redis", c.DB.Name) }}
+
+	assert.NoError(t, inj.Install(m))
+	assert.Equal(t, "decorated", inj.MustResolve("sessions").(*Server).DB.Name)
+}
+
+func TestInstall_PrivateProvidersCannotSeeOtherModules(t *testing.T) {
+	inj := NewInjector()
+	assert.NoError(t, inj.Install(newCacheModule("sessions", "redis")))
+
+	err := inj.Install(Module{
+		Name:      "reports",
+		Providers: []Provider{Provide(func(c *Cache) *UserService { return &UserService{} })},
+	})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, inj.Validate(), ErrNotFound)
+}
+
+func TestInstall_PrivateProvidersAreValidatedAndGraphed(t *testing.T) {
+	inj := NewInjector()
+	assert.NoError(t, inj.Install(Module{
+		Name:      "broken",
+		Providers: []Provider{Provide(func(c *Cache) *Database { return c.DB }).Private()},
+	}))
+
+	err := inj.Validate()
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorContains(t, err, `resolving *injector.Database (module "broken")`)
+
+	g := inj.Graph()
+	assert.Len(t, g.Nodes, 1)
+	assert.True(t, g.Nodes[0].Private)
+}
+
+func TestInstall_PrivateInstancesAreClosedAndStarted(t *testing.T) {
+	var events []string
+	inj := NewInjector()
+	assert.NoError(t, inj.Install(Module{
+		Name: "storage",
+		Providers: []Provider{
+			Provide(func() *closeRecorder { return &closeRecorder{name: "pool", events: &events} },
+				OnStart(func(ctx context.Context, c *closeRecorder) error {
+					events = append(events, "start "+c.name)
+					return nil
+				})).Private(),
+			Provide(func(pool *closeRecorder) *closerDependent {
+				return &closerDependent{closeRecorder: &closeRecorder{name: "repo", events: &events}}
+			}),
+		},
+	}))
+
+	assert.NoError(t, inj.Start(context.Background()))
+	Must[*closerDependent](inj)
+	assert.NoError(t, inj.Close())
+	assert.Equal(t, []string{"start pool", "close repo", "close pool"}, events)
+}
@@ -0,0 +1,57 @@
+package injector
+
+import (
+	"reflect"
+	"sort"
+)
+
+// stringType is the key type of maps resolved from named registrations.
+var stringType = reflect.TypeOf("")
+
+// isNamedMap reports whether t is a map[string]T that can collect named registrations.
+func isNamedMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key() == stringType
+}
+
+// namedRegistrations returns, sorted by name, the registrations made with InjectByName in
+// this container and its ancestors whose provided type is assignable to elem. A name
+// registered in a scope shadows the same name in its parents.
+func (i *Injector) namedRegistrations(elem reflect.Type) []*registration {
+	nearest := make(map[string]*registration)
+	for c := i; c != nil; c = c.parent {
+		c.mu.RLock()
+		for name, reg := range c.nameRegistrations {
+			if _, shadowed := nearest[name]; !shadowed {
+				nearest[name] = reg
+			}
+		}
+		c.mu.RUnlock()
+	}
+
+	var regs []*registration
+	for _, reg := range nearest {
+		if reg.typ != nil && reg.typ.AssignableTo(elem) {
+			regs = append(regs, reg)
+		}
+	}
+	sort.Slice(regs, func(a, b int) bool { return regs[a].name < regs[b].name })
+	return regs
+}
+
+// resolveNamedMap resolves every named registration into a new map of type mapType, keyed by name.
+func (i *Injector) resolveNamedMap(r *resolution, mapType reflect.Type, regs []*registration) (interface{}, error) {
+	m := reflect.MakeMapWithSize(mapType, len(regs))
+	for _, reg := range regs {
+		value, err := i.resolveName(r, reg.name)
+		if err != nil {
+			return nil, err
+		}
+
+		v := reflect.ValueOf(value)
+		if !v.IsValid() {
+			v = reflect.Zero(mapType.Elem())
+		}
+		m.SetMapIndex(reflect.ValueOf(reg.name), v)
+	}
+	return m.Interface(), nil
+}
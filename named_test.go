@@ -0,0 +1,96 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedMap_Get(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectByName(&Database{Name: "primary-db"}, "primary")
+	inj.InjectByName(func() *Database { return &Database{Name: "replica-db"} }, "replica")
+	inj.InjectByName(&UserService{}, "users")
+
+	dbs, err := Get[map[string]*Database](inj)
+	assert.NoError(t, err)
+	assert.Len(t, dbs, 2)
+	assert.Equal(t, "primary-db", dbs["primary"].Name)
+	assert.Equal(t, "replica-db", dbs["replica"].Name)
+
+	// Singleton factories are shared with Resolve
+	assert.Same(t, dbs["replica"], inj.MustResolve("replica"))
+}
+
+func TestNamedMap_InterfaceElements(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "db"})
+	inj.InjectByName(NewPostgresStorage, "postgres")
+	inj.InjectByName(&MemoryStorage{}, "memory")
+	inj.InjectByName(&RequestID{}, "request")
+
+	var storages map[string]Storage
+	assert.NoError(t, inj.ResolveInto(&storages))
+	assert.Len(t, storages, 2)
+	assert.IsType(t, &PostgresStorage{}, storages["postgres"])
+	assert.IsType(t, &MemoryStorage{}, storages["memory"])
+}
+
+func TestNamedMap_InvokeAndFactoryParameter(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectByName(&Database{Name: "a"}, "a")
+	inj.InjectByName(&Database{Name: "b"}, "b")
+	inj.Inject(func(dbs map[string]*Database) *UserRepository {
+		return &UserRepository{DB: dbs["b"]}
+	})
+
+	err := inj.Invoke(func(dbs map[string]*Database, repo *UserRepository) {
+		assert.Len(t, dbs, 2)
+		assert.Equal(t, "b", repo.DB.Name)
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, inj.Validate())
+}
+
+func TestNamedMap_ScopeShadowsParentNames(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectByName(&Database{Name: "root-primary"}, "primary")
+	inj.InjectByName(&Database{Name: "root-replica"}, "replica")
+
+	scope := inj.NewScope()
+	scope.InjectByName(&Database{Name: "scope-primary"}, "primary")
+	scope.InjectByName(&RequestContext{}, "replica")
+
+	dbs := Must[map[string]*Database](scope)
+	assert.Equal(t, map[string]*Database{"primary": {Name: "scope-primary"}}, dbs)
+
+	// The parent is unaffected
+	assert.Len(t, Must[map[string]*Database](inj), 2)
+}
+
+func TestNamedMap_ExplicitRegistrationWins(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectByName(&Database{Name: "named"}, "named")
+	inj.Inject(map[string]*Database{"explicit": {Name: "explicit"}})
+
+	dbs := Must[map[string]*Database](inj)
+	assert.Len(t, dbs, 1)
+	assert.Contains(t, dbs, "explicit")
+}
+
+func TestNamedMap_NoNamedRegistrations(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&UserService{})
+	inj.InjectByName(&RequestID{}, "request")
+
+	_, err := Get[map[string]*Database](inj)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestNamedMap_FactoryErrorPropagates(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectByName(func(repo *UserRepository) *Database { return &Database{} }, "broken")
+
+	_, err := Get[map[string]*Database](inj)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,78 @@
+package injector
+
+import (
+	"reflect"
+	"sync"
+)
+
+// ResolveEvent describes a registration being resolved. It is passed to the functions
+// added with Observe.
+type ResolveEvent struct {
+	// Label identifies the registration as in errors and graph output.
+	Label string
+	// Type is the type the registration provides.
+	Type reflect.Type
+	// Name is the registered name for name-based registrations.
+	Name string
+	// Group is the group name for registrations made with InjectGroup.
+	Group string
+	// Module is the name of the module that made the registration.
+	Module string
+}
+
+// observer is a function added with Observe.
+type observer struct {
+	fn func(ResolveEvent)
+}
+
+// Observe calls fn whenever a registration is resolved through this container or one of
+// its scopes, whether it is constructed or served from the cache. fn runs synchronously
+// on the resolving goroutine, so it must be fast and safe for concurrent use, and must not
+// resolve from the container. The returned function removes the observer.
+// Usage: remove := inj.Observe(func(e injector.ResolveEvent) { log.Println("resolved", e.Label) })
+func (i *Injector) Observe(fn func(ResolveEvent)) (remove func()) {
+	o := &observer{fn: fn}
+
+	i.mu.Lock()
+	i.observers = append(i.observers, o)
+	i.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			i.mu.Lock()
+			defer i.mu.Unlock()
+			for idx, existing := range i.observers {
+				if existing == o {
+					i.observers = append(i.observers[:idx:idx], i.observers[idx+1:]...)
+					break
+				}
+			}
+		})
+	}
+}
+
+// notify reports the resolution of reg to the observers of this container and its ancestors.
+func (i *Injector) notify(reg *registration) {
+	if reg == nil {
+		return
+	}
+
+	var observers []*observer
+	for c := i; c != nil; c = c.parent {
+		c.mu.RLock()
+		observers = append(observers, c.observers...)
+		c.mu.RUnlock()
+	}
+	if len(observers) == 0 {
+		return
+	}
+
+	event := ResolveEvent{Label: reg.label, Type: reg.typ, Group: reg.group, Module: reg.module}
+	if reg.named {
+		event.Name = reg.name
+	}
+	for _, o := range observers {
+		o.fn(event)
+	}
+}
@@ -0,0 +1,77 @@
+package injector
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserve_ReportsResolvedRegistrations(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.Inject(NewUserRepository)
+	inj.InjectByName(&UserService{}, "users")
+	inj.InjectGroup("health", &staticChecker{Name: "disk"})
+	inj.Inject(&RequestContext{})
+
+	var labels []string
+	remove := inj.Observe(func(e ResolveEvent) { labels = append(labels, e.Label) })
+
+	Must[*UserRepository](inj)
+	Must[*UserRepository](inj)
+	inj.MustResolve("users")
+	Must[[]HealthChecker](inj)
+
+	assert.Equal(t, []string{
+		"*injector.UserRepository",
+		"*injector.Database",
+		"*injector.UserRepository",
+		`"users"`,
+		`*injector.staticChecker (group "health")`,
+	}, labels)
+
+	remove()
+	remove()
+	Must[*RequestContext](inj)
+	assert.Len(t, labels, 5)
+}
+
+func TestObserve_EventFields(t *testing.T) {
+	inj := NewInjector()
+	assert.NoError(t, inj.Install(Module{
+		Name:      "db",
+		Providers: []Provider{ProvideNamed(NewDB, "primary")},
+	}))
+
+	var events []ResolveEvent
+	inj.Observe(func(e ResolveEvent) { events = append(events, e) })
+	inj.MustResolve("primary")
+
+	assert.Equal(t, []ResolveEvent{{
+		Label:  `"primary" (module "db")`,
+		Type:   reflect.TypeOf(&Database{}),
+		Name:   "primary",
+		Module: "db",
+	}}, events)
+}
+
+func TestObserve_ScopesReportToParentObservers(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "db"})
+
+	var mu sync.Mutex
+	count := 0
+	inj.Observe(func(e ResolveEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		count++
+	})
+
+	scope := inj.NewScope()
+	scope.Inject(&RequestContext{})
+	Must[*Database](scope)
+	Must[*RequestContext](scope)
+	assert.Equal(t, 2, count)
+}
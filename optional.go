@@ -0,0 +1,57 @@
+package injector
+
+import "reflect"
+
+// Optional asks for a dependency that may not be registered. Used as a factory or Invoke
+// parameter, an In field, or with Get, ResolveInto and Populate, it resolves T and sets
+// Found, or leaves Value at its zero value when nothing is registered for T.
+// Errors other than a missing registration, such as a failing factory, are still returned.
+// Usage: inj.Inject(func(tracer injector.Optional[*Tracer]) *Server { ... })
+type Optional[T any] struct {
+	Value T
+	Found bool
+}
+
+// optional is implemented by every Optional[T] so the container can recognize them.
+type optional interface {
+	optionalElem() reflect.Type
+}
+
+// optionalElem returns T.
+func (Optional[T]) optionalElem() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+var optionalType = reflect.TypeOf((*optional)(nil)).Elem()
+
+// optionalElem returns T when t is an Optional[T].
+func optionalElem(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Struct || !t.Implements(optionalType) {
+		return nil, false
+	}
+	return reflect.Zero(t).Interface().(optional).optionalElem(), true
+}
+
+// resolveOptional builds the Optional of type t by resolving its element type elem.
+func (i *Injector) resolveOptional(r *resolution, t, elem reflect.Type) (interface{}, error) {
+	inst, found, err := i.resolveType(r, elem)
+	if err != nil {
+		return nil, err
+	}
+
+	result := reflect.New(t).Elem()
+	if !found {
+		return result.Interface(), nil
+	}
+
+	value := reflect.ValueOf(inst)
+	if !value.IsValid() {
+		value = reflect.Zero(elem)
+	}
+	if !value.Type().AssignableTo(elem) {
+		return nil, typeMismatchError(r, elem, "resolved type %v is not assignable to %v", value.Type(), elem)
+	}
+	result.FieldByName("Value").Set(value)
+	result.FieldByName("Found").SetBool(true)
+	return result.Interface(), nil
+}
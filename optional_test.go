@@ -0,0 +1,90 @@
+package injector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptional_Get(t *testing.T) {
+	inj := NewInjector()
+
+	missing, err := Get[Optional[*Database]](inj)
+	assert.NoError(t, err)
+	assert.False(t, missing.Found)
+	assert.Nil(t, missing.Value)
+
+	inj.Inject(&Database{Name: "db"})
+	found, err := Get[Optional[*Database]](inj)
+	assert.NoError(t, err)
+	assert.True(t, found.Found)
+	assert.Equal(t, "db", found.Value.Name)
+}
+
+func TestOptional_FactoryParameter(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(func(db Optional[*Database]) *UserRepository {
+		if !db.Found {
+			return &UserRepository{DB: &Database{Name: "in-memory"}}
+		}
+		return &UserRepository{DB: db.Value}
+	})
+
+	assert.Equal(t, "in-memory", Must[*UserRepository](inj).DB.Name)
+	assert.NoError(t, inj.Validate())
+}
+
+func TestOptional_InvokeAndResolveInto(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewPostgresStorage)
+	inj.Inject(&Database{Name: "db"})
+
+	err := inj.Invoke(func(storage Optional[*PostgresStorage], checker Optional[HealthChecker]) {
+		assert.True(t, storage.Found)
+		assert.Equal(t, "db", storage.Value.DB.Name)
+		assert.False(t, checker.Found)
+		assert.Nil(t, checker.Value)
+	})
+	assert.NoError(t, err)
+
+	var db Optional[*Database]
+	assert.NoError(t, inj.ResolveInto(&db))
+	assert.True(t, db.Found)
+}
+
+func TestOptional_FactoryErrorIsReturned(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(func() (*Database, error) { return nil, errors.New("connection refused") })
+
+	_, err := Get[Optional[*Database]](inj)
+	assert.ErrorIs(t, err, ErrFactoryFailed)
+
+	// A missing parameter of the optional dependency is an error too
+	inj.Inject(NewUserRepository)
+	inj.Inject(func(repo Optional[*UserRepository]) *UserService { return &UserService{Repo: repo.Value} })
+	_, err = Get[*UserService](inj)
+	assert.ErrorIs(t, err, ErrFactoryFailed)
+}
+
+func TestOptional_ValidateReportsMissingInnerDependencies(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewUserRepository)
+	inj.Inject(func(repo Optional[*UserRepository]) *UserService { return &UserService{Repo: repo.Value} })
+
+	err := inj.Validate()
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "*injector.Database")
+}
+
+func TestOptional_InField(t *testing.T) {
+	inj := NewInjector()
+
+	err := inj.Invoke(func(p struct {
+		In
+		DB Optional[*Database]
+	}) {
+		assert.False(t, p.DB.Found)
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,138 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Lifetime controls how often a registered factory is called.
+type Lifetime int
+
+const (
+	// LifetimeSingleton calls the factory once and caches the result (default).
+	LifetimeSingleton Lifetime = iota
+	// LifetimeTransient calls the factory on every resolution.
+	LifetimeTransient
+	// LifetimeScoped calls the factory once per scope created with NewScope.
+	LifetimeScoped
+)
+
+// String returns the lifetime name.
+func (l Lifetime) String() string {
+	switch l {
+	case LifetimeSingleton:
+		return "singleton"
+	case LifetimeTransient:
+		return "transient"
+	case LifetimeScoped:
+		return "scoped"
+	default:
+		return "unknown"
+	}
+}
+
+// Option configures a registration made with Inject or InjectByName.
+// Usage: inj.Inject(NewRequestID, injector.Transient())
+type Option func(*registration)
+
+// Singleton makes the factory run once and caches its result. This is the default.
+func Singleton() Option {
+	return func(r *registration) {
+		r.lifetime = LifetimeSingleton
+	}
+}
+
+// Transient makes the factory run on every resolution.
+func Transient() Option {
+	return func(r *registration) {
+		r.lifetime = LifetimeTransient
+	}
+}
+
+// Scoped makes the factory run once per scope and caches the result in that scope.
+// Resolving a scoped dependency from the root container caches it in the root.
+func Scoped() Option {
+	return func(r *registration) {
+		r.lifetime = LifetimeScoped
+	}
+}
+
+// InjectorOption configures a container created with NewInjector.
+// Usage: inj := injector.NewInjector(injector.StrictTypes())
+type InjectorOption func(*Injector)
+
+// StrictTypes disables the type-name fallback, so For[T], Get[T], ResolveInto and Invoke
+// only resolve registrations of exactly the requested type.
+// ResolveByTypeName keeps matching by name since that is what it is asked to do.
+func StrictTypes() InjectorOption {
+	return func(i *Injector) {
+		i.strict = true
+	}
+}
+
+// registration holds the options a dependency was registered with.
+type registration struct {
+	// construction serializes construction of singleton instances
+	construction constructionLock
+
+	// typ is the provided type; named registrations are keyed by name instead
+	typ   reflect.Type
+	name  string
+	named bool
+	// group is the group name for registrations made with InjectGroup
+	group string
+	// module is the name of the module that made the registration, if any
+	module string
+	// private is set for private module providers
+	private bool
+	// resolver, if set, is the module container factory parameters are resolved from
+	resolver *Injector
+	label    string
+	// factory is the registered factory function, invalid for instances
+	factory reflect.Value
+
+	lifetime Lifetime
+	onStart  []hook
+	onStop   []hook
+	onClose  []hook
+}
+
+// newTypeRegistration builds a registration for type t from the given options.
+func newTypeRegistration(t reflect.Type, opts []Option) *registration {
+	r := newRegistration(opts)
+	r.typ = t
+	r.setLabel(t.String())
+	return r
+}
+
+// newNameRegistration builds a registration for a name from the given options.
+func newNameRegistration(name string, opts []Option) *registration {
+	r := newRegistration(opts)
+	r.name = name
+	r.named = true
+	r.setLabel(fmt.Sprintf("%q", name))
+	return r
+}
+
+// newRegistration applies the given options to a singleton registration.
+func newRegistration(opts []Option) *registration {
+	r := &registration{lifetime: LifetimeSingleton}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// setLabel sets the label used in errors and graphs, noting the module the
+// registration came from.
+func (r *registration) setLabel(label string) {
+	if r.module != "" {
+		label += fmt.Sprintf(" (module %q)", r.module)
+	}
+	r.label = label
+}
+
+// isFactory reports whether the dependency was registered with a factory function.
+func (r *registration) isFactory() bool {
+	return r.factory.IsValid()
+}
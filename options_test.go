@@ -0,0 +1,99 @@
+package injector
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type RequestID struct {
+	Value int
+}
+
+func newRequestIDFactory() func() *RequestID {
+	var counter int32
+	return func() *RequestID {
+		return &RequestID{Value: int(atomic.AddInt32(&counter, 1))}
+	}
+}
+
+func TestTransient_ForResolve(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(newRequestIDFactory(), Transient())
+
+	id1 := For[*RequestID](inj).MustResolve()
+	id2 := For[*RequestID](inj).MustResolve()
+
+	assert.Equal(t, 1, id1.Value)
+	assert.Equal(t, 2, id2.Value)
+	assert.NotSame(t, id1, id2)
+}
+
+func TestTransient_ResolveByName(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectByName(newRequestIDFactory(), "requestID", Transient())
+
+	id1 := inj.MustResolve("requestID").(*RequestID)
+	id2 := inj.MustResolve("requestID").(*RequestID)
+
+	assert.NotSame(t, id1, id2)
+	assert.Equal(t, 0, len(inj.dependencies))
+}
+
+func TestTransient_ResolveIntoAndInvoke(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(newRequestIDFactory(), Transient())
+
+	var id *RequestID
+	assert.NoError(t, inj.ResolveInto(&id))
+	assert.Equal(t, 1, id.Value)
+
+	err := inj.Invoke(func(id *RequestID) {
+		assert.Equal(t, 2, id.Value)
+	})
+	assert.NoError(t, err)
+}
+
+func TestSingleton_ExplicitOption(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(newRequestIDFactory(), Singleton())
+
+	id1 := For[*RequestID](inj).MustResolve()
+	id2 := For[*RequestID](inj).MustResolve()
+
+	assert.Same(t, id1, id2)
+}
+
+func TestLifetime_String(t *testing.T) {
+	assert.Equal(t, "singleton", LifetimeSingleton.String())
+	assert.Equal(t, "transient", LifetimeTransient.String())
+}
+
+func TestStrictTypes_DisablesTypeNameFallback(t *testing.T) {
+	inj := NewInjector(StrictTypes())
+	inj.Inject(&Database{Name: "db"})
+
+	_, err := Get[Database](inj)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = inj.Invoke(func(db Database) {})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	var db Database
+	assert.ErrorIs(t, inj.ResolveInto(&db), ErrNotFound)
+
+	// Exact matches and explicit type-name lookups still work
+	assert.Equal(t, "db", Must[*Database](inj).Name)
+	resolved, err := inj.ResolveByTypeName("Database")
+	assert.NoError(t, err)
+	assert.Equal(t, "db", resolved.(*Database).Name)
+}
+
+func TestStrictTypes_InheritedByScopes(t *testing.T) {
+	inj := NewInjector(StrictTypes())
+	inj.Inject(&Database{Name: "db"})
+
+	_, err := Get[Database](inj.NewScope())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
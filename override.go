@@ -0,0 +1,135 @@
+package injector
+
+import (
+	"maps"
+	"reflect"
+	"sync"
+)
+
+// Override replaces the registration of dependency's type in this container, like Inject,
+// and returns a function that restores the previous registration. Unlike registering
+// again with Inject, a singleton the previous registration had already cached is restored
+// along with it. Intended for tests; see the injectortest package.
+//
+// Dependents that were already constructed keep the instance they were built with, so
+// override before resolving them. Instances this container constructed while the override
+// was active on top of the replacement, directly or through other dependencies, are dropped
+// on restore and constructed again when next resolved; other instances are kept.
+// Restore functions should run in reverse order, as t.Cleanup does; calling one more
+// than once has no further effect.
+// Usage: restore := inj.Override(func() Storage { return fakeStorage }); defer restore()
+func (i *Injector) Override(dependency interface{}, opts ...Option) (restore func()) {
+	return i.override(func() { i.injectType(dependency, opts) })
+}
+
+// OverrideByName is like Override for a registration made with InjectByName.
+// Usage: restore := inj.OverrideByName(&Database{Name: "fake"}, "primary"); defer restore()
+func (i *Injector) OverrideByName(dependency interface{}, name string, opts ...Option) (restore func()) {
+	return i.override(func() { i.injectName(dependency, name, opts) })
+}
+
+// override runs register with i.mu held and returns a function restoring every type and
+// name registration it replaced, together with the value cached for it at this point,
+// and evicting the instances constructed since that depend on the replacement.
+func (i *Injector) override(register func()) func() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	built := len(i.built)
+	typeRegs := maps.Clone(i.typeRegistrations)
+	typeValues := maps.Clone(i.typeRegistry)
+	nameRegs := maps.Clone(i.nameRegistrations)
+	dependencies := maps.Clone(i.dependencies)
+	factories := maps.Clone(i.factories)
+
+	register()
+
+	var types []reflect.Type
+	replaced := make(map[*registration]bool)
+	for t, reg := range i.typeRegistrations {
+		if typeRegs[t] != reg {
+			types = append(types, t)
+			replaced[reg] = true
+		}
+	}
+	var names []string
+	for name, reg := range i.nameRegistrations {
+		if nameRegs[name] != reg {
+			names = append(names, name)
+			replaced[reg] = true
+		}
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			// Computed while the replacement is still registered, so its dependents resolve to it
+			stale := i.dependents(replaced)
+
+			i.mu.Lock()
+			for _, t := range types {
+				if reg, ok := typeRegs[t]; ok {
+					i.typeRegistrations[t] = reg
+					i.typeRegistry[t] = typeValues[t]
+				} else {
+					delete(i.typeRegistrations, t)
+					delete(i.typeRegistry, t)
+				}
+			}
+			for _, name := range names {
+				delete(i.dependencies, name)
+				delete(i.factories, name)
+				if dep, ok := dependencies[name]; ok {
+					i.dependencies[name] = dep
+				}
+				if factory, ok := factories[name]; ok {
+					i.factories[name] = factory
+				}
+				if reg, ok := nameRegs[name]; ok {
+					i.nameRegistrations[name] = reg
+				} else {
+					delete(i.nameRegistrations, name)
+				}
+			}
+			// Close empties the log, so everything in it was constructed during the override
+			if built > len(i.built) {
+				built = 0
+			}
+			var evicted []instance
+			for _, inst := range i.built[built:] {
+				if stale[inst.reg] {
+					evicted = append(evicted, inst)
+				}
+			}
+			i.mu.Unlock()
+
+			// Evicting locks the container each instance is cached in, which may be i
+			for _, inst := range evicted {
+				inst.evict()
+			}
+		})
+	}
+}
+
+// dependents returns regs together with every registration that depends on one of them,
+// directly or transitively, according to the static dependency graph.
+func (i *Injector) dependents(regs map[*registration]bool) map[*registration]bool {
+	_, edges, _ := i.dependencyGraph()
+	dependents := maps.Clone(regs)
+	for changed := true; changed; {
+		changed = false
+		for reg, deps := range edges {
+			if dependents[reg] {
+				continue
+			}
+			for _, dep := range deps {
+				if dependents[dep] {
+					dependents[reg] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return dependents
+}
@@ -0,0 +1,130 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverride_ReplacesCachedSingleton(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	original := Must[*Database](inj)
+
+	restore := inj.Override(&Database{Name: "fake"})
+	assert.Equal(t, "fake", Must[*Database](inj).Name)
+
+	restore()
+	assert.Same(t, original, Must[*Database](inj))
+
+	// Restoring again has no effect
+	inj.Inject(&Database{Name: "later"})
+	restore()
+	assert.Equal(t, "later", Must[*Database](inj).Name)
+}
+
+func TestOverride_RestoresUnresolvedFactory(t *testing.T) {
+	calls := 0
+	inj := NewInjector()
+	inj.Inject(func() *Database {
+		calls++
+		return &Database{Name: "real"}
+	})
+
+	restore := inj.Override(func() *Database { return &Database{Name: "fake"} })
+	assert.Equal(t, "fake", Must[*Database](inj).Name)
+	restore()
+
+	assert.Equal(t, "real", Must[*Database](inj).Name)
+	assert.Equal(t, 1, calls)
+}
+
+func TestOverride_UnregisteredType(t *testing.T) {
+	inj := NewInjector()
+
+	restore := inj.Override(func() Storage { return &MemoryStorage{} })
+	assert.IsType(t, &MemoryStorage{}, Must[Storage](inj))
+
+	restore()
+	_, err := Get[Storage](inj)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestOverride_Nested(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "real"})
+
+	restoreFirst := inj.Override(&Database{Name: "first"})
+	restoreSecond := inj.Override(&Database{Name: "second"})
+	assert.Equal(t, "second", Must[*Database](inj).Name)
+
+	restoreSecond()
+	assert.Equal(t, "first", Must[*Database](inj).Name)
+	restoreFirst()
+	assert.Equal(t, "real", Must[*Database](inj).Name)
+}
+
+func TestOverride_DependentsBuiltAfterward(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.Inject(NewUserRepository, Transient())
+
+	restore := inj.Override(&Database{Name: "fake"})
+	assert.Equal(t, "fake", Must[*UserRepository](inj).DB.Name)
+	restore()
+	assert.Equal(t, "db", Must[*UserRepository](inj).DB.Name)
+}
+
+func TestOverride_EvictsSingletonsBuiltOnReplacement(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.Inject(NewUserRepository)
+	inj.InjectByName(func(repo *UserRepository) *UserService { return &UserService{Repo: repo} }, "service")
+	inj.Inject(func() *Cache { return &Cache{} })
+	inj.Inject(func() *RequestContext { return &RequestContext{} })
+	cache := Must[*Cache](inj)
+
+	restore := inj.Override(&Database{Name: "fake"})
+	faked := Must[*UserRepository](inj)
+	assert.Equal(t, "fake", faked.DB.Name)
+	assert.Same(t, faked, inj.MustResolve("service").(*UserService).Repo)
+	unrelated := Must[*RequestContext](inj)
+	restore()
+
+	repo := Must[*UserRepository](inj)
+	assert.NotSame(t, faked, repo)
+	assert.Equal(t, "db", repo.DB.Name)
+	assert.Same(t, repo, inj.MustResolve("service").(*UserService).Repo)
+	// Singletons built before the override, or not built on the fake, are kept
+	assert.Same(t, cache, Must[*Cache](inj))
+	assert.Same(t, unrelated, Must[*RequestContext](inj))
+}
+
+func TestOverrideByName(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectByName(func() *Database { return &Database{Name: "primary"} }, "primary")
+	original := inj.MustResolve("primary")
+
+	restore := inj.OverrideByName(&Database{Name: "fake"}, "primary")
+	assert.Equal(t, "fake", inj.MustResolve("primary").(*Database).Name)
+	restore()
+	assert.Same(t, original, inj.MustResolve("primary"))
+
+	restore = inj.OverrideByName(&Database{Name: "extra"}, "extra")
+	assert.NotNil(t, inj.MustResolve("extra"))
+	restore()
+	_, err := inj.Resolve("extra")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestOverride_InScope(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "root"})
+	scope := inj.NewScope()
+
+	restore := scope.Override(&Database{Name: "scoped"})
+	assert.Equal(t, "scoped", Must[*Database](scope).Name)
+	assert.Equal(t, "root", Must[*Database](inj).Name)
+	restore()
+	assert.Equal(t, "root", Must[*Database](scope).Name)
+}
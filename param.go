@@ -0,0 +1,186 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// In marks a struct as a parameter object. A factory or Invoke function parameter whose
+// type embeds In is not resolved itself; each exported field is resolved instead, so one
+// function can ask for several instances of the same type. Fields accept these tags:
+//   - `name:"primary"` resolves the field by name, like Resolve
+//   - `group:"routes"` resolves the members of one group into a slice field, like Group
+//   - `optional:"true"` leaves the field at its zero value when nothing is registered
+//
+// Unexported fields are ignored.
+// Usage:
+//
+//	type Params struct {
+//		injector.In
+//		Primary *sql.DB `name:"primary"`
+//		Replica *sql.DB `name:"replica" optional:"true"`
+//	}
+type In struct{}
+
+// Out marks a struct as a result object. When a factory registered with Inject returns a
+// struct that embeds Out, each exported field is also registered, so one constructor can
+// provide several dependencies. Fields are registered by type unless tagged with
+// `name:"primary"` (registered by name) or `group:"routes"` (added to the group).
+//
+// The constructor is called once for all fields of a singleton, and once per resolved
+// field otherwise; field registrations share its lifetime. Lifecycle options receive the
+// Out struct. Unexported fields are ignored.
+// Usage:
+//
+//	type Databases struct {
+//		injector.Out
+//		Primary *sql.DB `name:"primary"`
+//		Replica *sql.DB `name:"replica"`
+//	}
+type Out struct{}
+
+var (
+	inType  = reflect.TypeOf(In{})
+	outType = reflect.TypeOf(Out{})
+)
+
+// embeds reports whether t is a struct that embeds the marker type.
+func embeds(t, marker reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for n := 0; n < t.NumField(); n++ {
+		if f := t.Field(n); f.Anonymous && f.Type == marker {
+			return true
+		}
+	}
+	return false
+}
+
+// markerFields returns the exported fields of a struct embedding marker, skipping the marker.
+func markerFields(t, marker reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for n := 0; n < t.NumField(); n++ {
+		f := t.Field(n)
+		if (f.Anonymous && f.Type == marker) || !f.IsExported() {
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// inDependency describes what a field of an In struct asks for.
+func inDependency(f reflect.StructField) dependency {
+	return dependency{
+		typ:      f.Type,
+		name:     f.Tag.Get("name"),
+		group:    f.Tag.Get("group"),
+		optional: f.Tag.Get("optional") == "true",
+	}
+}
+
+// resolveIn builds an In struct of type t by resolving each of its fields.
+func (i *Injector) resolveIn(r *resolution, t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	for _, f := range markerFields(t, inType) {
+		arg, err := i.resolveParam(r, inDependency(f))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.FieldByIndex(f.Index).Set(arg)
+	}
+	return v, nil
+}
+
+// provideOut registers every field of the Out struct produced by reg's factory.
+// Each field is provided by a factory taking the Out struct. The caller must hold i.mu.
+func (i *Injector) provideOut(reg *registration) {
+	// Field registrations share the constructor's lifetime, module and visibility
+	inherit := func(r *registration) {
+		r.lifetime = reg.lifetime
+		r.module = reg.module
+		r.private = reg.private
+	}
+
+	for _, f := range markerFields(reg.typ, outType) {
+		index := f.Index
+		fieldFactory := reflect.MakeFunc(
+			reflect.FuncOf([]reflect.Type{reg.typ}, []reflect.Type{f.Type}, false),
+			func(args []reflect.Value) []reflect.Value {
+				return []reflect.Value{args[0].FieldByIndex(index)}
+			},
+		).Interface()
+
+		switch name, group := f.Tag.Get("name"), f.Tag.Get("group"); {
+		case name != "":
+			i.injectName(fieldFactory, name, []Option{inherit})
+		case group != "":
+			i.injectGroup(group, fieldFactory, []Option{inherit})
+		default:
+			i.injectType(fieldFactory, []Option{inherit})
+		}
+	}
+}
+
+// resolveDependency resolves d by name, group or type. It reports false when nothing is
+// registered for d.
+func (i *Injector) resolveDependency(r *resolution, d dependency) (interface{}, bool, error) {
+	switch {
+	case d.name != "":
+		if _, ok := i.findName(d.name); !ok {
+			return nil, false, nil
+		}
+		value, err := i.resolveName(r, d.name)
+		return value, true, err
+	case d.group != "":
+		if d.typ.Kind() != reflect.Slice {
+			return nil, false, typeMismatchError(r, d.typ, "group %q must be resolved into a slice, not %v", d.group, d.typ)
+		}
+		members := i.groupMembers(d.group, d.typ.Elem())
+		if len(members) == 0 {
+			return nil, false, nil
+		}
+		value, err := i.resolveGroup(r, d.typ, members)
+		return value, true, err
+	default:
+		return i.resolveType(r, d.typ)
+	}
+}
+
+// resolveParam resolves a function parameter or In field described by d into a value
+// assignable to d.typ. Missing optional dependencies resolve to the zero value.
+func (i *Injector) resolveParam(r *resolution, d dependency) (reflect.Value, error) {
+	inst, found, err := i.resolveDependency(r, d)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if !found {
+		if d.optional {
+			return reflect.Zero(d.typ), nil
+		}
+		return reflect.Value{}, d.notFound(r)
+	}
+
+	arg := reflect.ValueOf(inst)
+	if !arg.IsValid() {
+		// A nil interface value still has to be passed as a typed zero value
+		arg = reflect.Zero(d.typ)
+	}
+	if !arg.Type().AssignableTo(d.typ) {
+		return reflect.Value{}, typeMismatchError(r, d.typ, "resolved type %v is not assignable to parameter type %v", arg.Type(), d.typ)
+	}
+	return arg, nil
+}
+
+// notFound returns the ErrNotFound error reported when nothing is registered for d.
+func (d dependency) notFound(r *resolution) *ResolveError {
+	switch {
+	case d.name != "":
+		return &ResolveError{Kind: ErrNotFound, Name: d.name, Path: r.path(), msg: fmt.Sprintf("dependency '%s' not found", d.name)}
+	case d.group != "":
+		return &ResolveError{Kind: ErrNotFound, Type: d.typ, Name: d.group, Path: r.path(), msg: fmt.Sprintf("no members found in group %q for type %v", d.group, d.typ.Elem())}
+	default:
+		return notFoundError(r, d.typ, "no dependency found for parameter type %v", d.typ)
+	}
+}
@@ -0,0 +1,163 @@
+package injector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type replicaParams struct {
+	In
+	Primary *Database       `name:"primary"`
+	Replica *Database       `name:"replica" optional:"true"`
+	Checks  []HealthChecker `group:"health"`
+	Repo    *UserRepository `optional:"true"`
+	ignored *Database
+}
+
+func TestIn_Invoke(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectByName(&Database{Name: "primary"}, "primary")
+	inj.InjectByName(&Database{Name: "replica"}, "replica")
+	inj.InjectGroup("health", &staticChecker{Name: "disk"})
+
+	err := inj.Invoke(func(p replicaParams) {
+		assert.Equal(t, "primary", p.Primary.Name)
+		assert.Equal(t, "replica", p.Replica.Name)
+		assert.Len(t, p.Checks, 1)
+		assert.Nil(t, p.Repo)
+		assert.Nil(t, p.ignored)
+	})
+	assert.NoError(t, err)
+}
+
+func TestIn_FactoryParameter(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectByName(&Database{Name: "primary"}, "primary")
+	inj.InjectGroup("health", &staticChecker{Name: "disk"})
+	inj.Inject(func(p replicaParams) *UserService {
+		assert.Nil(t, p.Replica)
+		return &UserService{Repo: &UserRepository{DB: p.Primary}}
+	})
+
+	svc, err := Get[*UserService](inj)
+	assert.NoError(t, err)
+	assert.Equal(t, "primary", svc.Repo.DB.Name)
+	assert.NoError(t, inj.Validate())
+}
+
+func TestIn_MissingRequiredField(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectGroup("health", &staticChecker{Name: "disk"})
+
+	err := inj.Invoke(func(p replicaParams) {})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	var resolveErr *ResolveError
+	assert.True(t, errors.As(err, &resolveErr))
+	assert.Equal(t, "primary", resolveErr.Name)
+}
+
+func TestIn_MissingGroup(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectByName(&Database{Name: "primary"}, "primary")
+
+	err := inj.Invoke(func(p replicaParams) {})
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), `group "health"`)
+}
+
+func TestIn_GroupTagRequiresSlice(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectGroup("health", &staticChecker{Name: "disk"})
+
+	err := inj.Invoke(func(p struct {
+		In
+		Check HealthChecker `group:"health"`
+	}) {
+	})
+	assert.ErrorIs(t, err, ErrTypeMismatch)
+}
+
+func TestIn_ValidateReportsMissingFields(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(func(p replicaParams) *UserService { return &UserService{} })
+
+	err := inj.Validate()
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "dependency 'primary' not found")
+	assert.Contains(t, err.Error(), `group "health"`)
+	assert.NotContains(t, err.Error(), "replica")
+}
+
+type databases struct {
+	Out
+	Primary *Database     `name:"primary"`
+	Replica *Database     `name:"replica"`
+	Checker HealthChecker `group:"health"`
+	Repo    *UserRepository
+}
+
+func newDatabases(calls *int) func() databases {
+	return func() databases {
+		*calls++
+		primary := &Database{Name: "primary"}
+		return databases{
+			Primary: primary,
+			Replica: &Database{Name: "replica"},
+			Checker: &dbChecker{DB: primary},
+			Repo:    &UserRepository{DB: primary},
+		}
+	}
+}
+
+func TestOut_RegistersEachField(t *testing.T) {
+	calls := 0
+	inj := NewInjector()
+	inj.Inject(newDatabases(&calls))
+
+	assert.Equal(t, "primary", inj.MustResolve("primary").(*Database).Name)
+	assert.Equal(t, "replica", inj.MustResolve("replica").(*Database).Name)
+	assert.Len(t, Must[[]HealthChecker](inj), 1)
+	repo := Must[*UserRepository](inj)
+	assert.Same(t, inj.MustResolve("primary"), repo.DB)
+
+	// The constructor runs once for every field of a singleton
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, inj.Validate())
+}
+
+func TestOut_TransientFields(t *testing.T) {
+	calls := 0
+	inj := NewInjector()
+	inj.Inject(newDatabases(&calls), Transient())
+
+	first := inj.MustResolve("primary")
+	second := inj.MustResolve("primary")
+	assert.NotSame(t, first, second)
+	assert.Equal(t, 2, calls)
+}
+
+func TestOut_ConstructorError(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(func() (databases, error) { return databases{}, errors.New("dial failed") })
+
+	_, err := inj.Resolve("primary")
+	assert.ErrorIs(t, err, ErrFactoryFailed)
+	assert.ErrorContains(t, err, "dial failed")
+}
+
+func TestOut_InFactoryChain(t *testing.T) {
+	calls := 0
+	inj := NewInjector()
+	inj.Inject(newDatabases(&calls))
+	inj.InjectGroup("health", &staticChecker{Name: "disk"})
+	inj.Inject(func(p replicaParams) *UserService {
+		return &UserService{Repo: &UserRepository{DB: p.Replica}}
+	})
+
+	svc := Must[*UserService](inj)
+	assert.Equal(t, "replica", svc.Repo.DB.Name)
+	assert.Len(t, Must[[]HealthChecker](inj), 2)
+}
@@ -0,0 +1,94 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// injectTag is the struct tag read by Populate.
+const injectTag = "inject"
+
+// Populate fills the exported fields of the struct target points to.
+// Fields tagged `inject:""` are resolved by type and fields tagged `inject:"name"` by name.
+// Adding ",optional" (e.g. `inject:",optional"`) leaves the field untouched when nothing is
+// registered for it. Untagged struct fields are populated recursively.
+// Usage: var h Handler; err := inj.Populate(&h)
+func (i *Injector) Populate(target interface{}) error {
+	if target == nil {
+		return fmt.Errorf("target is nil")
+	}
+
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("target must be a non-nil pointer to a struct, got %T", target)
+	}
+
+	return i.populate(v.Elem())
+}
+
+// populate fills the tagged fields of the struct value v.
+func (i *Injector) populate(v reflect.Value) error {
+	t := v.Type()
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		tag, tagged := field.Tag.Lookup(injectTag)
+
+		if !tagged {
+			if field.Type.Kind() == reflect.Struct && field.IsExported() {
+				if err := i.populate(v.Field(n)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !field.IsExported() {
+			return fmt.Errorf("cannot inject unexported field %v.%s", t, field.Name)
+		}
+
+		if err := i.populateField(v.Field(n), tag); err != nil {
+			return fmt.Errorf("field %v.%s: %w", t, field.Name, err)
+		}
+	}
+	return nil
+}
+
+// populateField resolves the dependency described by tag and assigns it to field.
+func (i *Injector) populateField(field reflect.Value, tag string) error {
+	name, optional := parseInjectTag(tag)
+	r := &resolution{}
+	value, found, err := i.resolveDependency(r, dependency{typ: field.Type(), name: name})
+	if err != nil {
+		return err
+	}
+	if !found {
+		if optional {
+			return nil
+		}
+		if name != "" {
+			return &ResolveError{Kind: ErrNotFound, Name: name, msg: fmt.Sprintf("dependency '%s' not found", name)}
+		}
+		return notFoundError(r, field.Type(), "no dependency found for type %v", field.Type())
+	}
+
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		rv = reflect.Zero(field.Type())
+	}
+	if !rv.Type().AssignableTo(field.Type()) {
+		return typeMismatchError(r, field.Type(), "resolved type %v is not assignable to %v", rv.Type(), field.Type())
+	}
+	field.Set(rv)
+	return nil
+}
+
+// parseInjectTag splits an inject tag into the dependency name and the optional flag.
+func parseInjectTag(tag string) (name string, optional bool) {
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if strings.TrimSpace(opt) == "optional" {
+			optional = true
+		}
+	}
+	return strings.TrimSpace(parts[0]), optional
+}
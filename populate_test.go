@@ -0,0 +1,133 @@
+package injector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Handlers struct {
+	Users    *UserService `inject:""`
+	Primary  *Database    `inject:"primary"`
+	Storage  Storage      `inject:",optional"`
+	Audit    *Database    `inject:"audit,optional"`
+	NotWired *Database
+	Admin    AdminHandlers
+}
+
+type AdminHandlers struct {
+	Repo *UserRepository `inject:""`
+}
+
+func TestPopulate_ByTypeAndName(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.Inject(NewUserRepository)
+	inj.Inject(NewUserService)
+	inj.InjectByName(&Database{Name: "primary"}, "primary")
+
+	var h Handlers
+	assert.NoError(t, inj.Populate(&h))
+
+	assert.Same(t, Must[*UserService](inj), h.Users)
+	assert.Equal(t, "primary", h.Primary.Name)
+	assert.Nil(t, h.Storage)
+	assert.Nil(t, h.Audit)
+	assert.Nil(t, h.NotWired)
+	assert.Same(t, Must[*UserRepository](inj), h.Admin.Repo)
+}
+
+func TestPopulate_OptionalKeepsExistingValue(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.Inject(NewUserRepository)
+	inj.Inject(NewUserService)
+	inj.InjectByName(&Database{Name: "primary"}, "primary")
+
+	memory := &MemoryStorage{}
+	h := Handlers{Storage: memory}
+	assert.NoError(t, inj.Populate(&h))
+	assert.Same(t, memory, h.Storage)
+}
+
+func TestPopulate_MissingRequiredField(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.Inject(NewUserRepository)
+	inj.Inject(NewUserService)
+
+	var h Handlers
+	err := inj.Populate(&h)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "field injector.Handlers.Primary")
+
+	var resolveErr *ResolveError
+	assert.True(t, errors.As(err, &resolveErr))
+	assert.Equal(t, "primary", resolveErr.Name)
+}
+
+func TestPopulate_MissingNestedField(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&UserService{})
+	inj.InjectByName(&Database{}, "primary")
+
+	var h Handlers
+	err := inj.Populate(&h)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "field injector.AdminHandlers.Repo")
+}
+
+func TestPopulate_OptionalDoesNotHideFactoryErrors(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(func() (*Database, error) { return nil, errors.New("connection refused") })
+
+	var target struct {
+		DB *Database `inject:",optional"`
+	}
+	err := inj.Populate(&target)
+	assert.ErrorIs(t, err, ErrFactoryFailed)
+}
+
+func TestPopulate_TypeMismatchByName(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectByName(&UserService{}, "db")
+
+	var target struct {
+		DB *Database `inject:"db"`
+	}
+	assert.ErrorIs(t, inj.Populate(&target), ErrTypeMismatch)
+}
+
+func TestPopulate_InvalidTargets(t *testing.T) {
+	inj := NewInjector()
+
+	var h Handlers
+	assert.Error(t, inj.Populate(nil))
+	assert.Error(t, inj.Populate(h))
+	assert.Error(t, inj.Populate((*Handlers)(nil)))
+
+	var db *Database
+	assert.Error(t, inj.Populate(&db))
+
+	var unexported struct {
+		db *Database `inject:""`
+	}
+	assert.ErrorContains(t, inj.Populate(&unexported), "unexported field")
+}
+
+func TestPopulate_FromScope(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(&Database{Name: "root"})
+
+	scope := inj.NewScope()
+	scope.Inject(&RequestContext{ID: "req-1"})
+
+	var target struct {
+		DB  *Database       `inject:""`
+		Req *RequestContext `inject:""`
+	}
+	assert.NoError(t, scope.Populate(&target))
+	assert.Equal(t, "root", target.DB.Name)
+	assert.Equal(t, "req-1", target.Req.ID)
+}
@@ -0,0 +1,154 @@
+package injector
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// resolution tracks the registrations being constructed by a single top-level
+// call such as Resolve, For[T].Resolve, ResolveInto or Invoke.
+type resolution struct {
+	stack []*registration
+}
+
+// enter records that reg is being constructed and returns a CycleError
+// if it is already being constructed further up the chain.
+func (r *resolution) enter(reg *registration) error {
+	for idx, pending := range r.stack {
+		if pending == reg {
+			path := r.path()[idx:]
+			return &CycleError{Path: append(path, reg.label)}
+		}
+	}
+	r.stack = append(r.stack, reg)
+	return nil
+}
+
+// leave removes the most recently entered registration.
+func (r *resolution) leave() {
+	r.stack = r.stack[:len(r.stack)-1]
+}
+
+// path returns the labels of the registrations currently being constructed.
+func (r *resolution) path() []string {
+	return labels(r.stack)
+}
+
+// labels returns the labels of regs.
+func labels(regs []*registration) []string {
+	if len(regs) == 0 {
+		return nil
+	}
+	path := make([]string, len(regs))
+	for idx, reg := range regs {
+		path[idx] = reg.label
+	}
+	return path
+}
+
+// constructionLock serializes the construction of a singleton or scoped instance.
+// Construction locks are held while parameters resolve, so a cycle that a single
+// resolution cannot see, such as a factory resolving from the container itself, a Lazy
+// used during construction, or two goroutines each building one side of a cycle, would
+// wait forever. The locks therefore record the goroutine holding them and the lock each
+// goroutine waits for, and lock refuses to wait when that would close a cycle.
+type constructionLock struct {
+	mu sync.Mutex
+	// reg and owner are guarded by waits.mu
+	reg   *registration
+	owner int64
+}
+
+// waits is the wait-for graph of the construction locks.
+var waits = struct {
+	mu sync.Mutex
+	// held lists the locks held by each goroutine, in the order they were taken
+	held map[int64][]*constructionLock
+	// waiting is the lock each blocked goroutine waits for
+	waiting map[int64]*constructionLock
+}{
+	held:    make(map[int64][]*constructionLock),
+	waiting: make(map[int64]*constructionLock),
+}
+
+// lock acquires the lock for the construction of reg, or returns a CycleError if
+// the lock is held by a goroutine that directly or transitively waits for this one.
+func (l *constructionLock) lock(reg *registration) error {
+	g := goroutineID()
+
+	waits.mu.Lock()
+	if path, ok := waitCycle(g, l, reg); ok {
+		waits.mu.Unlock()
+		return &CycleError{Path: path}
+	}
+	waits.waiting[g] = l
+	waits.mu.Unlock()
+
+	l.mu.Lock()
+
+	waits.mu.Lock()
+	delete(waits.waiting, g)
+	l.reg, l.owner = reg, g
+	waits.held[g] = append(waits.held[g], l)
+	waits.mu.Unlock()
+	return nil
+}
+
+// unlock releases the lock.
+func (l *constructionLock) unlock() {
+	waits.mu.Lock()
+	held := waits.held[l.owner]
+	for idx := len(held) - 1; idx >= 0; idx-- {
+		if held[idx] == l {
+			held = append(held[:idx], held[idx+1:]...)
+			break
+		}
+	}
+	if len(held) == 0 {
+		delete(waits.held, l.owner)
+	} else {
+		waits.held[l.owner] = held
+	}
+	l.reg, l.owner = nil, 0
+	waits.mu.Unlock()
+
+	l.mu.Unlock()
+}
+
+// waitCycle follows the wait-for graph from l, which goroutine g wants to lock for reg,
+// and returns the registrations on the cycle if it leads back to g. Every goroutine waits
+// for at most one lock and no wait closing a cycle is ever recorded, so the walk ends.
+// The caller must hold waits.mu.
+func waitCycle(g int64, l *constructionLock, reg *registration) ([]string, bool) {
+	var path []string
+	for next := l; next != nil && next.owner != 0; next = waits.waiting[next.owner] {
+		held := waits.held[next.owner]
+		for idx, h := range held {
+			if h == next {
+				for _, h := range held[idx:] {
+					path = append(path, h.reg.label)
+				}
+				break
+			}
+		}
+		if next.owner == g {
+			return append(path, reg.label), true
+		}
+	}
+	return nil, false
+}
+
+// goroutineID returns the id of the calling goroutine, parsed from the header of its
+// stack trace since the runtime does not expose it otherwise.
+func goroutineID() int64 {
+	var buf [64]byte
+	stack := buf[:runtime.Stack(buf[:], false)]
+	stack = bytes.TrimPrefix(stack, []byte("goroutine "))
+	if end := bytes.IndexByte(stack, ' '); end >= 0 {
+		stack = stack[:end]
+	}
+	id, _ := strconv.ParseInt(string(stack), 10, 64)
+	return id
+}
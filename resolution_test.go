@@ -0,0 +1,162 @@
+package injector
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCyclicRepository(svc *UserService) *UserRepository {
+	return &UserRepository{}
+}
+
+type (
+	cycleA struct{}
+	cycleB struct{}
+	cycleC struct{}
+	cycleD struct{}
+)
+
+// finishes fails the test if fn does not return within a few seconds, instead of hanging.
+func finishes(t *testing.T, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("resolution did not finish, a cycle is waiting for itself")
+	}
+}
+
+func TestCycle_TwoTypes(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewUserService)
+	inj.Inject(newCyclicRepository)
+
+	_, err := Get[*UserService](inj)
+
+	var cycleErr *CycleError
+	assert.True(t, errors.As(err, &cycleErr))
+	assert.Equal(t, []string{"*injector.UserService", "*injector.UserRepository", "*injector.UserService"}, cycleErr.Path)
+	assert.EqualError(t, err, "circular dependency detected: *injector.UserService -> *injector.UserRepository -> *injector.UserService")
+}
+
+func TestCycle_SelfReference(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(func(db *Database) *Database { return db })
+
+	var db *Database
+	err := inj.ResolveInto(&db)
+
+	var cycleErr *CycleError
+	assert.True(t, errors.As(err, &cycleErr))
+	assert.Equal(t, []string{"*injector.Database", "*injector.Database"}, cycleErr.Path)
+}
+
+func TestCycle_ReportsOnlyTheCycle(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(func(repo *UserRepository) *RequestContext { return &RequestContext{} })
+	inj.Inject(NewUserService)
+	inj.Inject(newCyclicRepository)
+
+	err := inj.Invoke(func(ctx *RequestContext) {})
+
+	var cycleErr *CycleError
+	assert.True(t, errors.As(err, &cycleErr))
+	assert.Equal(t, []string{"*injector.UserRepository", "*injector.UserService", "*injector.UserRepository"}, cycleErr.Path)
+}
+
+func TestCycle_ByName(t *testing.T) {
+	inj := NewInjector()
+	inj.InjectByName(newCyclicRepository, "repository")
+	inj.Inject(NewUserService)
+	inj.Inject(newCyclicRepository)
+
+	_, err := inj.Resolve("repository")
+
+	var cycleErr *CycleError
+	assert.True(t, errors.As(err, &cycleErr))
+	assert.Equal(t, "*injector.UserService", cycleErr.Path[0])
+}
+
+func TestCycle_TransientAndScoped(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewUserService, Transient())
+	inj.Inject(newCyclicRepository, Scoped())
+
+	_, err := Get[*UserService](inj.NewScope())
+
+	var cycleErr *CycleError
+	assert.True(t, errors.As(err, &cycleErr))
+}
+
+func TestCycle_SharedDependencyIsNotACycle(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.Inject(NewUserRepository, Transient())
+
+	// *Database is needed twice but never while it is itself being built
+	err := inj.Invoke(func(a *UserRepository, b *UserRepository, db *Database) {
+		assert.Same(t, a.DB, b.DB)
+	})
+	assert.NoError(t, err)
+}
+
+func TestCycle_FactoryResolvingFromContainer(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(func() (*cycleA, error) {
+		if _, err := For[*cycleB](inj).Resolve(); err != nil {
+			return nil, err
+		}
+		return &cycleA{}, nil
+	})
+	inj.Inject(func() (*cycleB, error) {
+		if _, err := For[*cycleA](inj).Resolve(); err != nil {
+			return nil, err
+		}
+		return &cycleB{}, nil
+	})
+
+	var err error
+	finishes(t, func() { _, err = Get[*cycleA](inj) })
+
+	var cycleErr *CycleError
+	assert.True(t, errors.As(err, &cycleErr))
+	assert.Equal(t, []string{"*injector.cycleA", "*injector.cycleB", "*injector.cycleA"}, cycleErr.Path)
+}
+
+func TestCycle_ConcurrentResolution(t *testing.T) {
+	// Each side is held while a slow dependency builds, so both goroutines are inside
+	// the cycle before either reaches the other side
+	slow := func() { time.Sleep(50 * time.Millisecond) }
+	inj := NewInjector()
+	inj.Inject(func() *cycleC { slow(); return &cycleC{} })
+	inj.Inject(func() *cycleD { slow(); return &cycleD{} })
+	inj.Inject(func(c *cycleC, b *cycleB) *cycleA { return &cycleA{} })
+	inj.Inject(func(d *cycleD, a *cycleA) *cycleB { return &cycleB{} })
+
+	var errA, errB error
+	finishes(t, func() {
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, errA = Get[*cycleA](inj)
+		}()
+		go func() {
+			defer wg.Done()
+			_, errB = Get[*cycleB](inj)
+		}()
+		wg.Wait()
+	})
+
+	assert.ErrorIs(t, errA, ErrCycle)
+	assert.ErrorIs(t, errB, ErrCycle)
+}
@@ -0,0 +1,17 @@
+package injector
+
+// NewScope creates a child container whose registrations shadow this container's.
+// Anything not registered in the scope is resolved from its parent chain.
+//
+// Singletons are cached in the container they were registered in, so app-wide
+// singletons resolved through a scope stay cached in the root. Factories registered
+// with Scoped() are called once per scope and cached in the scope that resolved them.
+// Usage: scope := inj.NewScope(); scope.Inject(&RequestContext{ID: id})
+func (i *Injector) NewScope() *Injector {
+	return newContainer(i)
+}
+
+// Parent returns the container this scope was created from, or nil for a root container.
+func (i *Injector) Parent() *Injector {
+	return i.parent
+}
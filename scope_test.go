@@ -0,0 +1,128 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type RequestContext struct {
+	ID string
+}
+
+func TestNewScope_FallsBackToParent(t *testing.T) {
+	root := NewInjector()
+	root.Inject(NewDB)
+	root.InjectByName(&Database{Name: "named"}, "database")
+
+	scope := root.NewScope()
+	assert.Same(t, root, scope.Parent())
+
+	db, err := For[*Database](scope).Resolve()
+	assert.NoError(t, err)
+	assert.Equal(t, "db", db.Name)
+
+	named, err := scope.Resolve("database")
+	assert.NoError(t, err)
+	assert.Equal(t, "named", named.(*Database).Name)
+
+	var intoDB *Database
+	assert.NoError(t, scope.ResolveInto(&intoDB))
+	assert.Same(t, db, intoDB)
+
+	err = scope.Invoke(func(d *Database) {
+		assert.Same(t, db, d)
+	})
+	assert.NoError(t, err)
+}
+
+func TestNewScope_ShadowsParent(t *testing.T) {
+	root := NewInjector()
+	root.Inject(&Database{Name: "root"})
+
+	scope := root.NewScope()
+	scope.Inject(&Database{Name: "scope"})
+
+	assert.Equal(t, "scope", Must[*Database](scope).Name)
+	assert.Equal(t, "root", Must[*Database](root).Name)
+}
+
+func TestNewScope_SingletonsCachedInRoot(t *testing.T) {
+	root := NewInjector()
+	root.Inject(NewDB)
+
+	db1 := Must[*Database](root.NewScope())
+	db2 := Must[*Database](root.NewScope())
+
+	assert.Same(t, db1, db2)
+	assert.Same(t, db1, Must[*Database](root))
+}
+
+func TestNewScope_ScopedLifetime(t *testing.T) {
+	root := NewInjector()
+	root.Inject(newRequestIDFactory(), Scoped())
+
+	scope1 := root.NewScope()
+	scope2 := root.NewScope()
+
+	id1a := Must[*RequestID](scope1)
+	id1b := Must[*RequestID](scope1)
+	id2 := Must[*RequestID](scope2)
+
+	assert.Same(t, id1a, id1b)
+	assert.NotSame(t, id1a, id2)
+	assert.Equal(t, 0, len(root.scoped))
+	assert.Equal(t, 1, len(scope1.scoped))
+}
+
+func TestNewScope_ScopedByName(t *testing.T) {
+	root := NewInjector()
+	root.InjectByName(newRequestIDFactory(), "requestID", Scoped())
+
+	scope := root.NewScope()
+	id1 := scope.MustResolve("requestID")
+	id2 := scope.MustResolve("requestID")
+	other := root.NewScope().MustResolve("requestID")
+
+	assert.Same(t, id1, id2)
+	assert.NotSame(t, id1, other)
+	assert.Equal(t, 0, len(root.dependencies))
+}
+
+func TestNewScope_ScopeOnlyRegistration(t *testing.T) {
+	root := NewInjector()
+	scope := root.NewScope()
+	scope.Inject(&RequestContext{ID: "req-1"})
+
+	ctx, err := Get[*RequestContext](scope)
+	assert.NoError(t, err)
+	assert.Equal(t, "req-1", ctx.ID)
+
+	_, err = Get[*RequestContext](root)
+	assert.Error(t, err)
+}
+
+func TestNewScope_SingletonFactoryUsesOwnerDependencies(t *testing.T) {
+	root := NewInjector()
+	root.Inject(&Database{Name: "root"})
+	root.Inject(NewUserRepository)
+
+	scope := root.NewScope()
+	scope.Inject(&Database{Name: "scope"})
+
+	// The singleton repository lives in the root, so it must not capture the scope's database
+	repo := Must[*UserRepository](scope)
+	assert.Equal(t, "root", repo.DB.Name)
+}
+
+func TestNewScope_ScopedFactoryUsesScopeDependencies(t *testing.T) {
+	root := NewInjector()
+	root.Inject(&Database{Name: "root"})
+	root.Inject(NewUserRepository, Scoped())
+
+	scope := root.NewScope()
+	scope.Inject(&Database{Name: "scope"})
+
+	repo := Must[*UserRepository](scope)
+	assert.Equal(t, "scope", repo.DB.Name)
+}
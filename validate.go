@@ -0,0 +1,246 @@
+package injector
+
+import (
+	"errors"
+	"reflect"
+)
+
+// dependency describes something a function asks the container for.
+type dependency struct {
+	typ reflect.Type
+	// name and group are set for In fields tagged with name or group
+	name     string
+	group    string
+	optional bool
+}
+
+// dependenciesOf lists what the container has to resolve to call a function of type ft.
+// Parameters that embed In contribute one dependency per field.
+func dependenciesOf(ft reflect.Type) []dependency {
+	var deps []dependency
+	for idx := 0; idx < ft.NumIn(); idx++ {
+		pType := ft.In(idx)
+		if !embeds(pType, inType) {
+			deps = append(deps, dependency{typ: pType})
+			continue
+		}
+		for _, f := range markerFields(pType, inType) {
+			deps = append(deps, inDependency(f))
+		}
+	}
+	return deps
+}
+
+// lookup finds the registrations that satisfy d without constructing anything,
+// following the same rules as resolveDependency. Missing optional dependencies
+// are not an error.
+func (i *Injector) lookup(r *resolution, d dependency) ([]*registration, error) {
+	if elem, ok := optionalElem(d.typ); ok && d.name == "" && d.group == "" {
+		if _, registered := i.findExactType(d.typ); !registered {
+			d = dependency{typ: elem, optional: true}
+		}
+	}
+
+	if elem, ok := deferredElem(d.typ); ok && d.name == "" && d.group == "" {
+		if _, registered := i.findExactType(d.typ); !registered {
+			// Deferred dependencies must be resolvable but are not part of construction,
+			// so they add no edge and cannot form a cycle
+			if _, err := i.lookup(r, dependency{typ: elem, optional: d.optional}); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		}
+	}
+
+	regs, err := i.lookupDependency(r, d)
+	if err != nil {
+		return nil, err
+	}
+	if len(regs) == 0 && !d.optional {
+		return nil, d.notFound(r)
+	}
+	return regs, nil
+}
+
+// lookupDependency finds the registrations that satisfy d, if any.
+func (i *Injector) lookupDependency(r *resolution, d dependency) ([]*registration, error) {
+	switch {
+	case d.name != "":
+		if reg, ok := i.findName(d.name); ok {
+			return []*registration{reg}, nil
+		}
+		return nil, nil
+	case d.group != "":
+		if d.typ.Kind() != reflect.Slice {
+			return nil, typeMismatchError(r, d.typ, "group %q must be resolved into a slice, not %v", d.group, d.typ)
+		}
+		return memberRegistrations(i.groupMembers(d.group, d.typ.Elem())), nil
+	}
+
+	if owner, ok := i.findExactType(d.typ); ok {
+		owner.mu.RLock()
+		defer owner.mu.RUnlock()
+		return []*registration{owner.typeRegistrations[d.typ]}, nil
+	}
+
+	if d.typ.Kind() == reflect.Slice {
+		members, err := i.sliceMembers(r, d.typ)
+		if err != nil {
+			return nil, err
+		}
+		if len(members) > 0 {
+			return memberRegistrations(members), nil
+		}
+	}
+
+	if isNamedMap(d.typ) {
+		if regs := i.namedRegistrations(d.typ.Elem()); len(regs) > 0 {
+			return regs, nil
+		}
+	}
+
+	owner, registeredType, ok, err := i.findFallbackType(r, d.typ)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	owner.mu.RLock()
+	defer owner.mu.RUnlock()
+	return []*registration{owner.typeRegistrations[registeredType]}, nil
+}
+
+// Validate checks the wiring of this container and its ancestors without calling any
+// factory: every factory parameter must be resolvable, type-name fallbacks must be
+// unambiguous and there must be no circular dependencies. All problems are returned
+// at once, joined with errors.Join; each one is a *ResolveError or *CycleError.
+// Usage: if err := inj.Validate(); err != nil { log.Fatal(err) }
+func (i *Injector) Validate() error {
+	nodes, edges, problems := i.dependencyGraph()
+	problems = append(problems, findCycles(nodes, edges)...)
+	return errors.Join(problems...)
+}
+
+// dependencyGraph statically resolves the factory parameters of every registration in
+// this container, its ancestors and their private module containers. It returns the registrations, root first and in
+// registration order, the registrations each one depends on, and every parameter that
+// could not be resolved.
+func (i *Injector) dependencyGraph() ([]*registration, map[*registration][]*registration, []error) {
+	var problems []error
+	var nodes []*registration
+	edges := make(map[*registration][]*registration)
+
+	for _, c := range i.containers() {
+		for _, reg := range c.currentRegistrations() {
+			nodes = append(nodes, reg)
+
+			// Mirror build: module providers resolve parameters from their module's container,
+			// singletons, registered instances and private providers from their own container
+			resolver := i
+			switch {
+			case reg.resolver != nil:
+				resolver = reg.resolver
+			case reg.lifetime == LifetimeSingleton || !reg.isFactory() || c.private:
+				resolver = c
+			}
+			for _, d := range c.registrationDependencies(reg) {
+				deps, err := resolver.lookup(&resolution{stack: []*registration{reg}}, d)
+				if err != nil {
+					problems = append(problems, err)
+					continue
+				}
+				edges[reg] = append(edges[reg], deps...)
+			}
+		}
+	}
+	return nodes, edges, problems
+}
+
+// registrationDependencies lists what the container has to resolve to construct reg,
+// registered in this container: its factory parameters followed by the parameters of
+// its decorators.
+func (i *Injector) registrationDependencies(reg *registration) []dependency {
+	var deps []dependency
+	if reg.isFactory() {
+		deps = dependenciesOf(reg.factory.Type())
+	}
+	for _, decorator := range i.decoratorsFor(reg) {
+		// The first parameter is the instance being decorated
+		deps = append(deps, dependenciesOf(decorator.Type())[1:]...)
+	}
+	return deps
+}
+
+// chain returns this container's ancestors, root first, followed by the container itself.
+func (i *Injector) chain() []*Injector {
+	var chain []*Injector
+	for c := i; c != nil; c = c.parent {
+		chain = append([]*Injector{c}, chain...)
+	}
+	return chain
+}
+
+// containers returns the containers of chain, each followed by its private module containers.
+func (i *Injector) containers() []*Injector {
+	var containers []*Injector
+	for _, c := range i.chain() {
+		containers = append(containers, c.withPrivates()...)
+	}
+	return containers
+}
+
+// withPrivates returns this container followed by its private module containers.
+func (i *Injector) withPrivates() []*Injector {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return append([]*Injector{i}, i.privates...)
+}
+
+// findCycles returns a CycleError for every distinct cycle in the dependency graph.
+func findCycles(nodes []*registration, edges map[*registration][]*registration) []error {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	var (
+		cycles []error
+		state  = make(map[*registration]int)
+		stack  []*registration
+		visit  func(reg *registration)
+	)
+
+	visit = func(reg *registration) {
+		state[reg] = visiting
+		stack = append(stack, reg)
+
+		for _, dep := range edges[reg] {
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				// dep is on the stack, so the stack from dep onwards forms a cycle
+				var path []string
+				for idx := len(stack) - 1; idx >= 0; idx-- {
+					if stack[idx] == dep {
+						for _, p := range stack[idx:] {
+							path = append(path, p.label)
+						}
+						break
+					}
+				}
+				cycles = append(cycles, &CycleError{Path: append(path, dep.label)})
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[reg] = done
+	}
+
+	for _, reg := range nodes {
+		if state[reg] == unvisited {
+			visit(reg)
+		}
+	}
+	return cycles
+}
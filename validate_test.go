@@ -0,0 +1,88 @@
+package injector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate_ValidContainer(t *testing.T) {
+	called := false
+	inj := NewInjector()
+	inj.Inject(func() *Database {
+		called = true
+		return &Database{}
+	})
+	inj.Inject(NewUserRepository)
+	inj.Inject(NewUserService)
+	inj.InjectByName(NewUserRepository, "repository")
+
+	assert.NoError(t, inj.Validate())
+	assert.False(t, called, "Validate must not call factories")
+}
+
+func TestValidate_ReportsAllMissingDependencies(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewUserRepository)
+	inj.InjectByName(NewFileService, "files")
+
+	err := inj.Validate()
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "no dependency found for parameter type *injector.Database (resolving *injector.UserRepository)")
+	assert.Contains(t, err.Error(), `no dependency found for parameter type injector.Storage (resolving "files")`)
+}
+
+func TestValidate_DetectsCycles(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewUserService)
+	inj.Inject(newCyclicRepository)
+	inj.Inject(func(db *Database) *Database { return db })
+
+	err := inj.Validate()
+
+	var cycles []*CycleError
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var cycleErr *CycleError
+		if errors.As(e, &cycleErr) {
+			cycles = append(cycles, cycleErr)
+		}
+	}
+	assert.Len(t, cycles, 2)
+	assert.Equal(t, []string{"*injector.UserService", "*injector.UserRepository", "*injector.UserService"}, cycles[0].Path)
+	assert.Equal(t, []string{"*injector.Database", "*injector.Database"}, cycles[1].Path)
+}
+
+func TestValidate_DetectsAmbiguity(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewDB)
+	inj.Inject(newOtherDatabase("other"))
+	inj.Inject(func(db Database) *Cache { return &Cache{} })
+
+	assert.ErrorIs(t, inj.Validate(), ErrAmbiguous)
+}
+
+func TestValidate_Scopes(t *testing.T) {
+	root := NewInjector()
+	root.Inject(NewUserRepository, Scoped())
+
+	scope := root.NewScope()
+	scope.Inject(NewDB)
+
+	// Scoped factories resolve their parameters from the scope
+	assert.NoError(t, scope.Validate())
+	assert.ErrorIs(t, root.Validate(), ErrNotFound)
+
+	// Singletons resolve their parameters from the container they are registered in
+	root.Inject(NewUserService)
+	root.Inject(NewUserRepository)
+	assert.ErrorIs(t, scope.Validate(), ErrNotFound)
+}
+
+func TestValidate_ReplacedRegistrationsAreIgnored(t *testing.T) {
+	inj := NewInjector()
+	inj.Inject(NewUserRepository)
+	inj.Inject(&UserRepository{})
+
+	assert.NoError(t, inj.Validate())
+}